@@ -0,0 +1,36 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// backup snapshots the labels plan is about to delete or overwrite when a
+// backup directory is configured.
+func backup(opts *options, plan *github.Plan) error {
+	if len(opts.backupDir) == 0 || !plan.Destructive() {
+		return nil
+	}
+	path, err := github.WriteBackup(opts.backupDir, opts.backupFormat, plan, time.Now())
+	if err != nil {
+		return fmt.Errorf("unable to back up labels: %w", err)
+	}
+	fmt.Printf("labels on: %s/%s backed up to: %s\n", plan.Owner, plan.Repo, path)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// badge is the shields.io endpoint format.
+type badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// writeBadge writes the status of a sync failing with err, if any, and its
+// date to the badge-file input if set, for a "labels in sync" badge.
+func writeBadge(opts *options, err error) error {
+	if len(opts.badgeFile) == 0 {
+		return nil
+	}
+	b := badge{
+		SchemaVersion: 1,
+		Label:         "labels",
+		Message:       "in sync · " + time.Now().UTC().Format("2006-01-02"),
+		Color:         "brightgreen",
+	}
+	if err != nil {
+		b.Message = "sync failed · " + time.Now().UTC().Format("2006-01-02")
+		b.Color = "red"
+	}
+	buf, e := json.Marshal(b)
+	if e != nil {
+		return e
+	}
+	if e := ioutil.WriteFile(opts.badgeFile, append(buf, '\n'), 0644); e != nil {
+		return fmt.Errorf("unable to write badge: %w", e)
+	}
+	return nil
+}
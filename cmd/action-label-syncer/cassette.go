@@ -0,0 +1,60 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/micnncim/action-label-syncer/pkg/cassette"
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// startCassette makes every HTTP request go through a recorder if the record
+// input is set, or be answered from the cassette of the replay input, and
+// returns the function saving the recording.
+func startCassette(opts *options) (func() error, error) {
+	switch {
+	case len(opts.record) > 0 && len(opts.replay) > 0:
+		return nil, errors.New("record and replay are mutually exclusive")
+	case len(opts.record) > 0:
+		r := &cassette.Recorder{Transport: http.DefaultTransport}
+		http.DefaultClient.Transport = r
+		return func() error {
+			if err := r.Save(opts.record); err != nil {
+				return fmt.Errorf("unable to save cassette: %w", err)
+			}
+			fmt.Printf("cassette: recorded to %s\n", opts.record)
+			return nil
+		}, nil
+	case len(opts.replay) > 0:
+		r, err := cassette.Load(opts.replay)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load cassette: %w", err)
+		}
+		http.DefaultClient.Transport = r
+	}
+	return func() error { return nil }, nil
+}
+
+// startLimiter makes every HTTP request of the run, to any repository, share
+// the request-rate budget.
+func startLimiter(opts *options) {
+	if opts.requestRate <= 0 {
+		return
+	}
+	http.DefaultClient.Transport = github.NewLimiter(http.DefaultClient.Transport, opts.requestRate)
+}
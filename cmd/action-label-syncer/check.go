@@ -0,0 +1,175 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+const linkTimeout = 10 * time.Second
+
+// runCheck fails if anything is out of line without changing anything: the
+// manifest is invalid, a link in a description is broken if checked, the
+// issue templates or labeler.yml reference labels missing from the manifest,
+// or the labels of a target have drifted from it beyond the drift baseline.
+func runCheck(ctx context.Context, opts *options) error {
+	m, err := validateManifest(opts)
+	if m != nil {
+		err = multierr.Combine(err, validateConfig(m), github.ValidateReserved(m.Labels, opts.reservedPrefixes()), opts.naming.Validate(m.Labels))
+	}
+	if err != nil {
+		return reportProblems(opts.manifest, multierr.Errors(err))
+	}
+
+	var problems []error
+	if opts.strictAccessibility {
+		problems = append(problems, github.LowContrastLabels(m.Labels)...)
+	} else {
+		checkContrast(opts, m.Labels)
+	}
+
+	if opts.checkLinks {
+		problems = append(problems, checkLinks(ctx, m.Labels)...)
+	}
+
+	refs, err := github.ReferencedLabels(opts.githubDir)
+	if err != nil {
+		return fmt.Errorf("unable to read label references: %w", err)
+	}
+	managed := make(map[string]bool)
+	for _, l := range m.Labels {
+		managed[strings.ToLower(l.Name)] = true
+	}
+	var missing []string
+	for name := range refs {
+		if !managed[strings.ToLower(name)] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		problems = append(problems, fmt.Errorf("label %q is referenced by %s but missing from manifest", name, strings.Join(refs[name], ", ")))
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	problems = append(problems, multierr.Errors(checkSets(targets, m.Labels))...)
+	if len(targets) > 0 {
+		provider, err := newProvider(opts)
+		if err != nil {
+			return err
+		}
+		syncer := newSyncer(opts, provider)
+		baseline, err := github.ReadBaseline(opts.driftBaseline)
+		if err != nil {
+			return fmt.Errorf("unable to read drift baseline: %w", err)
+		}
+		for _, t := range targets {
+			t, err := resolveTarget(ctx, opts, provider, nil, t)
+			if err == errSkipped {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			plan, err := syncer.PlanLabels(ctx, t.owner, t.repo, t.labels(m.Labels), opts.prune)
+			if err != nil {
+				return fmt.Errorf("unable to plan labels: %w", err)
+			}
+			for _, c := range github.Collisions(plan.Current, m.Labels) {
+				problems = append(problems, fmt.Errorf("%s on: %s/%s", c, t.owner, t.repo))
+			}
+			// Labels created by hand break the policy too.
+			for _, e := range multierr.Errors(opts.naming.Validate(plan.Current)) {
+				problems = append(problems, fmt.Errorf("%v on: %s/%s", e, t.owner, t.repo))
+			}
+			if opts.updateBaseline {
+				baseline.Accept(plan)
+				continue
+			}
+			drift := baseline.NewDrift(plan)
+			if len(drift) > 0 {
+				plan.Operations = drift
+				fmt.Print(plan)
+				problems = append(problems, fmt.Errorf("labels on %s/%s have drifted from manifest (%d operation(s))", t.owner, t.repo, len(drift)))
+			}
+		}
+		if opts.updateBaseline {
+			if err := github.WriteBaseline(opts.driftBaseline, baseline); err != nil {
+				return fmt.Errorf("unable to write drift baseline: %w", err)
+			}
+			fmt.Printf("drift baseline: %s updated\n", opts.driftBaseline)
+		}
+	}
+
+	if len(problems) > 0 {
+		return reportProblems(opts.manifest, problems)
+	}
+	fmt.Printf("manifest: %s checked, no problems found\n", opts.manifest)
+	return nil
+}
+
+// checkLinks returns a problem for every URL in the descriptions of labels not
+// responding with a 2xx status.
+func checkLinks(ctx context.Context, labels []github.Label) []error {
+	links := github.DescriptionLinks(labels)
+	urls := make([]string, 0, len(links))
+	for u := range links {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+
+	client := &http.Client{Timeout: linkTimeout}
+	var problems []error
+	for _, u := range urls {
+		if err := github.CheckLink(ctx, client, u); err != nil {
+			problems = append(problems, fmt.Errorf("label %s: broken link: %v", strings.Join(quote(links[u]), ", "), err))
+		}
+	}
+	return problems
+}
+
+func quote(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return quoted
+}
+
+func reportProblems(manifest string, problems []error) error {
+	for _, p := range problems {
+		fmt.Printf("%s: %v\n", manifest, p)
+	}
+	return fmt.Errorf("check failed: %s: %d problem(s) found", manifest, len(problems))
+}
+
+// warnCollisions prints the labels of the repository of plan colliding with
+// labels, which explain failures to create labels that seem not to exist.
+func warnCollisions(plan *github.Plan, labels []github.Label) {
+	for _, c := range github.Collisions(plan.Current, labels) {
+		github.Warnf("%s on: %s/%s", c, plan.Owner, plan.Repo)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// colorValue matches the color values of a manifest: the key, and the value
+// unquoted, double-quoted or single-quoted.
+var colorValue = regexp.MustCompile(`(?m)((?:^|[{,])[ \t]*(?:-[ \t]+)?color:[ \t]*)("[^"\n]*"|'[^'\n]*'|[^\s#,}\]]+)`)
+
+// runColors reports the manifest colors not in the canonical form of 6
+// lowercase hex digits without '#'. With --fix it rewrites the fixable ones in
+// place, keeping comments and layout, or reformats the whole manifest with
+// --sort; invalid colors are left for a human to fix.
+func runColors(opts *options) error {
+	m, err := github.ParseManifest(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+
+	var fixed, invalid int
+	for i, l := range m.Labels {
+		c, err := github.CanonicalColor(l.Color)
+		if err != nil {
+			fmt.Printf("%s: label %q: %v\n", opts.manifest, l.Name, err)
+			invalid++
+			continue
+		}
+		if c == l.Color {
+			continue
+		}
+		fmt.Printf("%s: label %q: color %q should be %q\n", opts.manifest, l.Name, l.Color, c)
+		m.Labels[i].Color = c
+		fixed++
+	}
+
+	if fixed > 0 && opts.fix {
+		buf, err := fixColors(opts, m, fixed)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(opts.manifest, buf, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("manifest: %s: %d color(s) fixed\n", opts.manifest, fixed)
+		fixed = 0
+	}
+	if fixed+invalid > 0 {
+		return fmt.Errorf("colors: %s: %d problem(s) found", opts.manifest, fixed+invalid)
+	}
+	fmt.Printf("manifest: %s colors are canonical\n", opts.manifest)
+	return nil
+}
+
+// fixColors returns the manifest with the colors of m. Without --sort only the
+// color values are replaced, and it fails if they can't all be found, e.g.
+// because of anchors.
+func fixColors(opts *options, m *github.Manifest, fixed int) ([]byte, error) {
+	if len(opts.sort) > 0 {
+		return github.FormatManifest(m, opts.sort)
+	}
+	buf, err := ioutil.ReadFile(opts.manifest)
+	if err != nil {
+		return nil, err
+	}
+	var n int
+	buf = colorValue.ReplaceAllFunc(buf, func(match []byte) []byte {
+		sub := colorValue.FindSubmatch(match)
+		v := string(sub[2])
+		if q := v[0]; (q == '"' || q == '\'') && len(v) > 1 {
+			v = strings.Trim(v, string(q))
+		}
+		c, err := github.CanonicalColor(v)
+		if err != nil || c == v {
+			return match
+		}
+		n++
+		return append(append([]byte{}, sub[1]...), `"`+c+`"`...)
+	})
+	if n != fixed {
+		return nil, fmt.Errorf("colors: %s: unable to fix %d color(s) in place, use --sort to reformat the manifest", opts.manifest, fixed-n)
+	}
+	return buf, nil
+}
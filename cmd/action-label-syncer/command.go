@@ -0,0 +1,141 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const commandPrefix = "/sync-labels"
+
+// commentEvent holds the fields of the issue_comment event payload the
+// command mode uses.
+type commentEvent struct {
+	Comment struct {
+		Body              string `json:"body"`
+		AuthorAssociation string `json:"author_association"`
+		User              struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"comment"`
+	Issue struct {
+		Number      int              `json:"number"`
+		PullRequest *json.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+}
+
+// authorizedAssociations are the author associations allowed to run commands,
+// i.e. people with write access to the repository.
+var authorizedAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+// runCommand runs the /sync-labels command of the pull request comment of the
+// triggering event: `/sync-labels` syncs and `/sync-labels plan` only plans.
+// The outcome is commented back on the pull request.
+func runCommand(ctx context.Context, opts *options) error {
+	e, err := readCommentEvent(os.Getenv("GITHUB_EVENT_PATH"))
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(e.Comment.Body)
+	if len(fields) == 0 || fields[0] != commandPrefix || e.Issue.PullRequest == nil {
+		fmt.Printf("comment: on #%d is not a %s command on a pull request\n", e.Issue.Number, commandPrefix)
+		return nil
+	}
+
+	targets, err := parseTargets(os.Getenv("GITHUB_REPOSITORY"))
+	if err != nil {
+		return err
+	}
+	if len(targets) != 1 {
+		return errors.New("command requires $GITHUB_REPOSITORY")
+	}
+	t := targets[0]
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+	reply := func(body string) error {
+		if err := client.CreateComment(ctx, t.owner, t.repo, e.Issue.Number, body); err != nil {
+			return fmt.Errorf("unable to comment: %w", err)
+		}
+		return nil
+	}
+
+	user := e.Comment.User.Login
+	if !authorizedAssociations[e.Comment.AuthorAssociation] {
+		fmt.Printf("command: %s by: %s refused, not a collaborator\n", commandPrefix, user)
+		return reply(fmt.Sprintf("@%s only collaborators can run `%s`.", user, commandPrefix))
+	}
+
+	var run func(context.Context, *options) error
+	switch sub := strings.Join(fields[1:], " "); sub {
+	case "", "sync":
+		run = runSync
+	case "plan":
+		run = runPlan
+	default:
+		return reply(fmt.Sprintf("@%s unknown command `%s %s`; use `%s` or `%s plan`.", user, commandPrefix, sub, commandPrefix, commandPrefix))
+	}
+
+	fmt.Printf("command: %s by: %s on: %s/%s#%d\n", e.Comment.Body, user, t.owner, t.repo, e.Issue.Number)
+	cmdErr := run(ctx, opts)
+	outcome := "succeeded"
+	if cmdErr != nil {
+		outcome = fmt.Sprintf("failed: %v", cmdErr)
+	}
+	body := fmt.Sprintf("`%s` by @%s %s.", strings.Join(fields, " "), user, outcome)
+	if url := runURL(); len(url) > 0 {
+		body += fmt.Sprintf(" See the [log](%s).", url)
+	}
+	if err := reply(body); err != nil {
+		return err
+	}
+	return cmdErr
+}
+
+// readCommentEvent returns the issue_comment event payload at path.
+func readCommentEvent(path string) (*commentEvent, error) {
+	if len(path) == 0 {
+		return nil, errors.New("command requires an issue_comment event")
+	}
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read event: %w", err)
+	}
+	var e commentEvent
+	if err := json.Unmarshal(buf, &e); err != nil {
+		return nil, fmt.Errorf("unable to parse event: %w", err)
+	}
+	return &e, nil
+}
+
+// runURL returns the URL of the workflow run, or "" outside GitHub Actions.
+func runURL() string {
+	server, repo, id := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if len(server) == 0 || len(repo) == 0 || len(id) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, id)
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v2"
+)
+
+// applyConfig fills the flags of fs not in set from the config file given by
+// the config input. Its top-level keys are input names, except profiles,
+// which maps profile names to more inputs overriding the top-level ones when
+// the profile is selected by the profile input. A config with labels is its
+// own manifest unless one is given.
+func applyConfig(fs *flag.FlagSet, set map[string]bool) error {
+	path := fs.Lookup("config").Value.String()
+	profile := fs.Lookup("profile").Value.String()
+	if len(path) == 0 {
+		if len(profile) > 0 {
+			return fmt.Errorf("profile %s requires a config file", profile)
+		}
+		return nil
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read config: %w", err)
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(buf, &config); err != nil {
+		return fmt.Errorf("unable to parse config: %s: %w", path, err)
+	}
+	profiles, err := parseProfiles(config["profiles"])
+	if err != nil {
+		return fmt.Errorf("unable to parse profiles: %s: %w", path, err)
+	}
+
+	inputs := make(map[string]interface{})
+	for k, v := range config {
+		switch k {
+		case "profiles":
+		case "labels", "milestones", "emoji":
+			if _, ok := config["manifest"]; !ok {
+				inputs["manifest"] = path
+			}
+		default:
+			inputs[k] = v
+		}
+	}
+	if len(profile) > 0 {
+		p, ok := profiles[profile]
+		if !ok {
+			return fmt.Errorf("unknown profile: %s", profile)
+		}
+		for k, v := range p {
+			inputs[k] = v
+		}
+	}
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if set[name] {
+			continue
+		}
+		if fs.Lookup(name) == nil {
+			err = multierr.Append(err, fmt.Errorf("unknown input in config: %s", name))
+			continue
+		}
+		if e := fs.Set(name, configValue(inputs[name])); e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to parse %s: %w", name, e))
+		}
+	}
+	return err
+}
+
+// parseProfiles returns the profiles of a config, v being the value of its
+// profiles key.
+func parseProfiles(v interface{}) (map[string]map[string]interface{}, error) {
+	var profiles map[string]map[string]interface{}
+	if v == nil {
+		return profiles, nil
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(b, &profiles)
+	return profiles, err
+}
+
+// validateConfig returns an error for every key of the config part of m that
+// isn't an input, including in profiles.
+func validateConfig(m *github.Manifest) error {
+	fs := newFlagSet(&options{})
+	var err error
+	for k := range m.Config {
+		if k != "profiles" && fs.Lookup(k) == nil {
+			err = multierr.Append(err, fmt.Errorf("unknown input in config: %s", k))
+		}
+	}
+	profiles, e := parseProfiles(m.Config["profiles"])
+	if e != nil {
+		return multierr.Append(err, fmt.Errorf("unable to parse profiles: %w", e))
+	}
+	for name, p := range profiles {
+		for k := range p {
+			if fs.Lookup(k) == nil {
+				err = multierr.Append(err, fmt.Errorf("unknown input in profile %s: %s", name, k))
+			}
+		}
+	}
+	return err
+}
+
+// configValue returns v as a flag value. Lists, e.g. of repositories, are
+// newline-separated.
+func configValue(v interface{}) string {
+	list, ok := v.([]interface{})
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	items := make([]string, len(list))
+	for i, item := range list {
+		items[i] = fmt.Sprint(item)
+	}
+	return strings.Join(items, "\n")
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+var errAborted = errors.New("aborted by user")
+
+// isInteractive reports whether stdin is a terminal, i.e. someone can answer
+// prompts.
+func isInteractive() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// checkEmptyManifest refuses to sync no labels with prune unless the
+// allow-empty-manifest input is set, as it would delete every label and
+// usually means a mistake in the manifest, e.g. in its indentation.
+func checkEmptyManifest(opts *options, labels []github.Label) error {
+	if len(labels) > 0 || !opts.prune || opts.allowEmptyManifest {
+		return nil
+	}
+	return errors.New("refusing to sync no labels with prune, which deletes every label; set allow-empty-manifest to do so")
+}
+
+// checkDeletions refuses plan if it deletes more labels than the max-deletions
+// input allows without force, which usually means the manifest has been
+// emptied by accident.
+func checkDeletions(opts *options, plan *github.Plan) error {
+	if opts.force || opts.maxDeletions < 0 {
+		return nil
+	}
+	n := 0
+	for _, op := range plan.Operations {
+		if op.Kind == github.OperationDelete {
+			n++
+		}
+	}
+	if n > opts.maxDeletions {
+		return fmt.Errorf("refusing to delete %d labels from %s/%s (max-deletions is %d); set force to delete them", n, plan.Owner, plan.Repo, opts.maxDeletions)
+	}
+	return nil
+}
+
+// printEstimate prints the API calls applying plans takes and how long they
+// are estimated to take with the concurrency input. With the github provider,
+// it also tells when the rate limit won't last and the run will have to wait
+// for it to reset.
+func printEstimate(ctx context.Context, opts *options, plans []*github.Plan) {
+	e := github.EstimatePlans(plans, opts.concurrency).AtRate(opts.requestRate)
+	if e.Calls == 0 {
+		return
+	}
+	if opts.provider != "github" || len(opts.snapshot) > 0 {
+		fmt.Printf("estimate: %d API call(s), about %s\n", e.Calls, e.Duration)
+		return
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		github.Warnf("%v", err)
+		return
+	}
+	limit, err := client.RateLimit(ctx)
+	if err != nil {
+		github.Warnf("unable to get rate limit: %v", err)
+		fmt.Printf("estimate: %d API call(s), about %s\n", e.Calls, e.Duration)
+		return
+	}
+	if limit.Remaining >= e.Calls {
+		fmt.Printf("estimate: %d API call(s), about %s, %d left in the rate limit\n", e.Calls, e.Duration, limit.Remaining)
+		return
+	}
+	wait := time.Until(limit.Reset).Round(time.Second)
+	fmt.Printf("estimate: %d API call(s), about %s plus %s waiting for the rate limit to reset, %d left in the rate limit\n", e.Calls, e.Duration, wait, limit.Remaining)
+}
+
+// confirmDeletions asks for every deletion in plan whether it should run and
+// drops the declined ones from plan. Answering "all" accepts the remaining
+// deletions on the repository and "quit" aborts the whole run.
+func confirmDeletions(plan *github.Plan, in *bufio.Reader) error {
+	ops := plan.Operations[:0]
+	all := false
+	for _, op := range plan.Operations {
+		if op.Kind != github.OperationDelete || all {
+			ops = append(ops, op)
+			continue
+		}
+
+		fmt.Printf("Delete label %q from %s/%s? [y/N/a(ll)/q(uit)] ", op.Label.Name, plan.Owner, plan.Repo)
+		answer, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			ops = append(ops, op)
+		case "a", "all":
+			all = true
+			ops = append(ops, op)
+		case "q", "quit":
+			return errAborted
+		default:
+			fmt.Printf("label: %s kept on: %s/%s\n", op.Label.Name, plan.Owner, plan.Repo)
+		}
+	}
+	plan.Operations = ops
+	return nil
+}
+
+// confirm asks question and reports whether it was answered with yes.
+func confirm(in *bufio.Reader, question string) (bool, error) {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
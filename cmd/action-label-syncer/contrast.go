@@ -0,0 +1,41 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// checkContrast prints a warning for every label hard to read because of its
+// color, and fails with the strict-accessibility input.
+func checkContrast(opts *options, labels []github.Label) error {
+	problems := github.LowContrastLabels(labels)
+	if len(problems) == 0 {
+		return nil
+	}
+	for _, p := range problems {
+		if opts.strictAccessibility {
+			fmt.Printf("%s: %v\n", opts.manifest, p)
+		} else {
+			github.Warnf("%s: %v", opts.manifest, p)
+		}
+	}
+	if opts.strictAccessibility {
+		return fmt.Errorf("inaccessible colors: %s: %d problem(s) found", opts.manifest, len(problems))
+	}
+	return nil
+}
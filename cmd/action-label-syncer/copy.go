@@ -0,0 +1,61 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runCopy syncs the labels of one repository to others directly, without a
+// manifest.
+func runCopy(ctx context.Context, opts *options) error {
+	from, err := parseTargets(opts.from)
+	if err != nil {
+		return err
+	}
+	if len(from) != 1 {
+		return errors.New("copy requires exactly one source repository")
+	}
+	to, err := parseTargets(opts.to)
+	if err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		return errors.New("copy requires a destination repository")
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	labels, err := provider.ListLabels(ctx, from[0].owner, from[0].repo)
+	if err != nil {
+		return fmt.Errorf("unable to list labels: %w", err)
+	}
+	// A lock held on the source must not be copied as a label.
+	copied := labels[:0]
+	for _, l := range labels {
+		if l.Name == opts.lockLabel || github.IsLockMarker(l) {
+			continue
+		}
+		copied = append(copied, l)
+	}
+	return syncTargets(ctx, opts, provider, to, copied, nil)
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/cron"
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runDaemon syncs the targets on the cron schedule input, serving /healthz
+// and /readyz on addr for container orchestrators. It's ready once a sync has
+// succeeded and unready while the last sync has failed.
+func runDaemon(ctx context.Context, opts *options) error {
+	schedule, err := cron.Parse(opts.schedule)
+	if err != nil {
+		return err
+	}
+	// Nobody is there to answer confirmation prompts.
+	opts.yes = true
+
+	var ready int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&ready) == 0 {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- http.ListenAndServe(opts.addr, mux)
+	}()
+	fmt.Printf("listening on: %s\n", opts.addr)
+
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return errors.New("schedule never runs")
+		}
+		fmt.Printf("next sync at: %s\n", next.Format(time.RFC3339))
+
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(next)):
+		}
+
+		since := github.Warnings()
+		err := runSync(ctx, opts)
+		if err == nil {
+			err = checkWarnings(opts, since)
+		}
+		if err != nil {
+			atomic.StoreInt32(&ready, 0)
+			log.Printf("unable to sync: %v", err)
+			continue
+		}
+		atomic.StoreInt32(&ready, 1)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// doctor prints the outcome of checks and counts the failed ones.
+type doctor struct {
+	failed int
+}
+
+func (d *doctor) pass(format string, a ...interface{}) {
+	fmt.Printf("pass: "+format+"\n", a...)
+}
+
+func (d *doctor) fail(format string, a ...interface{}) {
+	d.failed++
+	fmt.Printf("FAIL: "+format+"\n", a...)
+}
+
+// runDoctor checks that syncing can work: the API is reachable, the token is
+// valid and has the scopes needed, the repositories can have their labels
+// managed, and the rate limit isn't exhausted. It changes nothing.
+func runDoctor(ctx context.Context, opts *options) error {
+	d := &doctor{}
+	if len(opts.token) == 0 {
+		d.fail("token: none given with token, token-file or $GITHUB_TOKEN")
+		return errors.New("doctor: 1 check(s) failed")
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Token(ctx)
+	if err != nil {
+		d.fail("api: %s: %v", client.APIURL(), err)
+		return fmt.Errorf("doctor: %d check(s) failed", d.failed)
+	}
+	d.pass("api: %s reachable", client.APIURL())
+	switch {
+	case len(token.Login) > 0:
+		d.pass("token: valid, authenticated as: %s", token.Login)
+	default:
+		d.pass("token: valid, not acting as a user, e.g. of a GitHub App")
+	}
+	switch {
+	case token.Scopes == nil:
+		d.pass("scopes: none to check, e.g. fine-grained token")
+	case token.HasScope("public_repo"):
+		d.pass("scopes: %s", strings.Join(token.Scopes, ", "))
+	default:
+		d.fail("scopes: %q lacks repo or public_repo", strings.Join(token.Scopes, ", "))
+	}
+
+	limit, err := client.RateLimit(ctx)
+	switch {
+	case err != nil:
+		d.fail("rate limit: %v", err)
+	case limit.Remaining == 0:
+		d.fail("rate limit: exhausted until %s", limit.Reset.Format(time.RFC3339))
+	default:
+		d.pass("rate limit: %d call(s) left until %s", limit.Remaining, limit.Reset.Format(time.RFC3339))
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		ok, err := client.CanManageLabels(ctx, t.owner, t.repo)
+		switch {
+		case err != nil:
+			d.fail("repository: %s/%s: %v", t.owner, t.repo, err)
+		case !ok:
+			d.fail("repository: %s/%s: read-only, managing labels takes write access", t.owner, t.repo)
+		default:
+			d.pass("repository: %s/%s: labels can be managed", t.owner, t.repo)
+		}
+	}
+
+	if d.failed > 0 {
+		return fmt.Errorf("doctor: %d check(s) failed", d.failed)
+	}
+	return nil
+}
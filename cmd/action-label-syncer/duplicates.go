@@ -0,0 +1,89 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// runMergeDuplicates merges near-duplicate labels on every target into one
+// label, preferring the spelling used by the manifest if there is one.
+func runMergeDuplicates(ctx context.Context, opts *options) error {
+	// The manifest is optional here: it only decides which spelling wins.
+	var preferred []github.Label
+	if m, err := loadManifest(opts); err == nil {
+		preferred = m.Labels
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+
+	var in *bufio.Reader
+	if !opts.yes && isInteractive() {
+		in = bufio.NewReader(os.Stdin)
+	}
+
+	for _, t := range targets {
+		labels, e := client.ListLabels(ctx, t.owner, t.repo)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to list labels: %w", e))
+			continue
+		}
+		groups := github.FindDuplicates(labels, preferred)
+		if len(groups) == 0 {
+			fmt.Printf("no duplicate labels on: %s/%s\n", t.owner, t.repo)
+			continue
+		}
+
+		for _, g := range groups {
+			names := make([]string, 0, len(g.Duplicates))
+			for _, d := range g.Duplicates {
+				names = append(names, d.Name)
+			}
+			question := fmt.Sprintf("Merge %s into %s on %s/%s?", strings.Join(names, ", "), g.Canonical.Name, t.owner, t.repo)
+			if opts.dryRun {
+				fmt.Printf("labels: %s will be merged into %s on: %s/%s\n", strings.Join(names, ", "), g.Canonical.Name, t.owner, t.repo)
+				continue
+			}
+			if in != nil {
+				ok, e := confirm(in, question)
+				if e != nil {
+					return multierr.Append(err, e)
+				}
+				if !ok {
+					continue
+				}
+			}
+			if e := client.MergeDuplicates(ctx, t.owner, t.repo, g); e != nil {
+				err = multierr.Append(err, fmt.Errorf("unable to merge labels: %w", e))
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// secretInputs are redacted from the effective config.
+var secretInputs = map[string]bool{
+	"token":          true,
+	"webhook-secret": true,
+	"notify-url":     true,
+}
+
+// writeEffectiveConfig writes the inputs as resolved from flags, environment
+// variables, config files and defaults, the labels of the manifest as loaded
+// and the labels synced to each target to the effective-config input, if set.
+// With "-", it's printed instead.
+func writeEffectiveConfig(opts *options, labels []github.Label, targets []target) error {
+	if len(opts.effectiveConfig) == 0 {
+		return nil
+	}
+
+	var inputs yaml.MapSlice
+	opts.inputs.VisitAll(func(f *flag.Flag) {
+		var v interface{} = f.Value.String()
+		if g, ok := f.Value.(flag.Getter); ok {
+			v = g.Get()
+		}
+		if secretInputs[f.Name] && len(f.Value.String()) > 0 {
+			v = "(redacted)"
+		}
+		inputs = append(inputs, yaml.MapItem{Key: f.Name, Value: v})
+	})
+	var repositories yaml.MapSlice
+	for _, t := range targets {
+		names := []string{}
+		for _, l := range t.labels(labels) {
+			names = append(names, l.Name)
+		}
+		repositories = append(repositories, yaml.MapItem{Key: t.owner + "/" + t.repo, Value: names})
+	}
+
+	buf, err := yaml.Marshal(yaml.MapSlice{
+		{Key: "mode", Value: opts.mode},
+		{Key: "inputs", Value: inputs},
+		{Key: "labels", Value: labels},
+		{Key: "repositories", Value: repositories},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write effective config: %w", err)
+	}
+	if opts.effectiveConfig == "-" {
+		fmt.Printf("--- effective config\n%s", buf)
+		return nil
+	}
+	if err := ioutil.WriteFile(opts.effectiveConfig, buf, 0644); err != nil {
+		return fmt.Errorf("unable to write effective config: %w", err)
+	}
+	fmt.Printf("effective config: written to %s\n", opts.effectiveConfig)
+	return nil
+}
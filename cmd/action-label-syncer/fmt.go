@@ -0,0 +1,54 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runFmt rewrites the manifest in its canonical form, or with --check only
+// reports whether it already is.
+func runFmt(opts *options) error {
+	m, err := github.ParseManifest(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	buf, err := github.FormatManifest(m, opts.sort)
+	if err != nil {
+		return err
+	}
+
+	current, err := ioutil.ReadFile(opts.manifest)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, buf) {
+		fmt.Printf("manifest: %s already formatted\n", opts.manifest)
+		return nil
+	}
+	if opts.check {
+		return fmt.Errorf("manifest: %s is not formatted; run `action-label-syncer fmt --manifest %s`", opts.manifest, opts.manifest)
+	}
+
+	if err := ioutil.WriteFile(opts.manifest, buf, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("manifest: %s formatted\n", opts.manifest)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// includeGenerated returns targets followed by the repositories generated from
+// the template repositories among them, with the same settings, if the
+// include-generated input is set. Repositories already listed aren't added
+// again.
+func includeGenerated(ctx context.Context, opts *options, targets []target) ([]target, error) {
+	if !opts.includeGenerated {
+		return targets, nil
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		seen[strings.ToLower(t.owner+"/"+t.repo)] = true
+	}
+	included := targets
+	for _, t := range targets {
+		template, err := client.IsTemplate(ctx, t.owner, t.repo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get repository: %w", err)
+		}
+		if !template {
+			continue
+		}
+		names, err := client.GeneratedRepositories(ctx, t.owner, t.repo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list repositories generated from %s/%s: %w", t.owner, t.repo, err)
+		}
+		for _, name := range names {
+			if key := strings.ToLower(t.owner + "/" + name); !seen[key] {
+				seen[key] = true
+				g := t
+				g.repo = name
+				included = append(included, g)
+				fmt.Printf("repository: %s/%s included, generated from template: %s/%s\n", g.owner, g.repo, t.owner, t.repo)
+			}
+		}
+	}
+	return included, nil
+}
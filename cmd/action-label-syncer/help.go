@@ -0,0 +1,166 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func printUsage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "Usage: %s [mode] [flags]\n\nModes:\n", command)
+	for _, m := range modes {
+		fmt.Fprintf(out, "  %-17s %s\n", m.name, m.usage)
+	}
+	fmt.Fprintf(out, "\nFlags (each can also be set with INPUT_<NAME>, or LABEL_SYNCER_<NAME> with underscores for hyphens):\n")
+	fs.PrintDefaults()
+}
+
+// runHelp prints the usage, or with the json argument a machine-readable
+// description of the modes and flags for tools wrapping the command.
+func runHelp(opts *options) error {
+	fs := newFlagSet(&options{})
+	if len(opts.args) == 0 {
+		fs.SetOutput(os.Stdout)
+		printUsage(fs)
+		return nil
+	}
+	if opts.args[0] != "json" {
+		return fmt.Errorf("unknown help format: %s", opts.args[0])
+	}
+
+	type modeJSON struct {
+		Name  string `json:"name"`
+		Usage string `json:"usage"`
+	}
+	type flagJSON struct {
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Default string `json:"default"`
+		Usage   string `json:"usage"`
+		Env     string `json:"env"`
+		// EnvAlias is the variable for CI systems other than GitHub Actions.
+		EnvAlias string `json:"env_alias"`
+	}
+	v := struct {
+		Name  string     `json:"name"`
+		Modes []modeJSON `json:"modes"`
+		Flags []flagJSON `json:"flags"`
+	}{
+		Name: command,
+	}
+	for _, m := range modes {
+		v.Modes = append(v.Modes, modeJSON{Name: m.name, Usage: m.usage})
+	}
+	fs.VisitAll(func(f *flag.Flag) {
+		v.Flags = append(v.Flags, flagJSON{
+			Name:     f.Name,
+			Type:     flagType(f),
+			Default:  f.DefValue,
+			Usage:    f.Usage,
+			Env:      "INPUT_" + strings.ToUpper(f.Name),
+			EnvAlias: envName(f.Name),
+		})
+	})
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func flagType(f *flag.Flag) string {
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool"
+	}
+	if g, ok := f.Value.(flag.Getter); ok {
+		return fmt.Sprintf("%T", g.Get())
+	}
+	return "string"
+}
+
+// runCompletion prints the completion script for the shell given as argument.
+func runCompletion(opts *options) error {
+	if len(opts.args) == 0 {
+		return errors.New("completion requires a shell: bash, zsh or fish")
+	}
+	fs := newFlagSet(&options{})
+
+	var b strings.Builder
+	switch shell := opts.args[0]; shell {
+	case "bash":
+		var names, flags []string
+		for _, m := range modes {
+			names = append(names, m.name)
+		}
+		fs.VisitAll(func(f *flag.Flag) {
+			flags = append(flags, "--"+f.Name)
+		})
+		fmt.Fprintf(&b, `_action_label_syncer() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  if [ "$COMP_CWORD" -eq 1 ] && [[ "$cur" != -* ]]; then
+    COMPREPLY=($(compgen -W "%s" -- "$cur"))
+    return
+  fi
+  COMPREPLY=($(compgen -W "%s" -- "$cur"))
+}
+complete -o default -F _action_label_syncer %s
+`, strings.Join(names, " "), strings.Join(flags, " "), command)
+	case "zsh":
+		var names []string
+		for _, m := range modes {
+			names = append(names, fmt.Sprintf("'%s:%s'", m.name, zshEscape(m.usage)))
+		}
+		fmt.Fprintf(&b, "#compdef %s\n\n_arguments \\\n", command)
+		fs.VisitAll(func(f *flag.Flag) {
+			spec := fmt.Sprintf("--%s[%s]", f.Name, zshEscape(f.Usage))
+			if flagType(f) != "bool" {
+				spec += ":" + f.Name + ":_files"
+			}
+			fmt.Fprintf(&b, "  '%s' \\\n", spec)
+		})
+		fmt.Fprintf(&b, "  '1:mode:((%s))'\n", strings.Join(names, " "))
+	case "fish":
+		for _, m := range modes {
+			fmt.Fprintf(&b, "complete -c %s -n __fish_use_subcommand -f -a %s -d '%s'\n", command, m.name, fishEscape(m.usage))
+		}
+		fs.VisitAll(func(f *flag.Flag) {
+			fmt.Fprintf(&b, "complete -c %s -l %s -d '%s'", command, f.Name, fishEscape(f.Usage))
+			if flagType(f) != "bool" {
+				b.WriteString(" -r")
+			}
+			b.WriteString("\n")
+		})
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	fmt.Print(b.String())
+	return nil
+}
+
+func zshEscape(s string) string {
+	r := strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`)
+	return r.Replace(s)
+}
+
+func fishEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return r.Replace(s)
+}
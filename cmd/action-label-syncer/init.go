@@ -0,0 +1,49 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runInit writes a starter manifest from one of the built-in templates. It
+// never overwrites an existing manifest.
+func runInit(opts *options) error {
+	labels, err := github.Template(opts.template)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(opts.manifest); err == nil {
+		return fmt.Errorf("manifest: %s already exists", opts.manifest)
+	}
+
+	buf, err := github.FormatLabels(labels, "group")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.manifest), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(opts.manifest, buf, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("manifest: %s created from template: %s (%d labels)\n", opts.manifest, opts.template, len(labels))
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// keepUsedLabels removes from plan the deletions of labels still used by
+// issues, pull requests or discussions if the keep-used input is set.
+func keepUsedLabels(ctx context.Context, opts *options, plan *github.Plan) error {
+	if !opts.keepUsed || !plan.Destructive() {
+		return nil
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+	used, err := client.LabelsInUse(ctx, plan.Owner, plan.Repo)
+	if err != nil {
+		return fmt.Errorf("unable to list labels in use: %w", err)
+	}
+	printKept(opts, plan, plan.Keep(used), "still in use")
+	return nil
+}
+
+// keepNewLabels removes from plan the deletions of labels created within the
+// last grace-days days, giving people time to add them to the manifest.
+func keepNewLabels(ctx context.Context, opts *options, plan *github.Plan) error {
+	if opts.graceDays <= 0 || !plan.Destructive() {
+		return nil
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+	since := time.Now().AddDate(0, 0, -opts.graceDays)
+	created, err := client.LabelsCreatedSince(ctx, plan.Owner, plan.Repo, since)
+	if err != nil {
+		return fmt.Errorf("unable to list new labels: %w", err)
+	}
+	printKept(opts, plan, plan.Keep(created), fmt.Sprintf("created within %d day(s)", opts.graceDays))
+	return nil
+}
+
+// keepDefaultLabels removes from plan the deletions of GitHub's default labels
+// if the keep-default-labels input is set, as many tools assume they exist.
+func keepDefaultLabels(opts *options, plan *github.Plan) {
+	if !opts.keepDefaultLabels {
+		return
+	}
+	defaults := make(map[string]bool, len(github.DefaultLabels))
+	for _, l := range github.DefaultLabels {
+		defaults[strings.ToLower(l.Name)] = true
+	}
+	names := make(map[string]bool)
+	for _, op := range plan.Operations {
+		if op.Kind == github.OperationDelete && defaults[strings.ToLower(op.Label.Name)] {
+			names[op.Label.Name] = true
+		}
+	}
+	printKept(opts, plan, plan.Keep(names), "default label")
+}
+
+// printKept prints the labels kept on plan for reason one per line with
+// verbose, or only how many otherwise.
+func printKept(opts *options, plan *github.Plan, kept []github.Label, reason string) {
+	if opts.verbose {
+		for _, l := range kept {
+			fmt.Printf("label: %s kept on: %s/%s, %s\n", l.Name, plan.Owner, plan.Repo, reason)
+		}
+		return
+	}
+	if len(kept) > 0 {
+		fmt.Printf("labels: %d kept on: %s/%s, %s\n", len(kept), plan.Owner, plan.Repo, reason)
+	}
+}
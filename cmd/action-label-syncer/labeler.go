@@ -0,0 +1,119 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// issueEvent holds the fields of the issues and pull_request event payloads
+// the labeler uses.
+type issueEvent struct {
+	Issue       *eventIssue `json:"issue"`
+	PullRequest *eventIssue `json:"pull_request"`
+}
+
+type eventIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+// runLabelIssue adds the labels whose match rules match an issue, either the
+// one given by the issue input or the one of the event triggering the
+// workflow.
+func runLabelIssue(ctx context.Context, opts *options) error {
+	m, err := loadManifest(opts)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	if len(targets) != 1 {
+		return errors.New("label-issue requires exactly one repository")
+	}
+	t := targets[0]
+
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+
+	var issue *eventIssue
+	if opts.issue > 0 {
+		title, body, err := client.GetIssue(ctx, t.owner, t.repo, opts.issue)
+		if err != nil {
+			return fmt.Errorf("unable to get issue: %w", err)
+		}
+		issue = &eventIssue{Number: opts.issue, Title: title, Body: body}
+	} else {
+		issue, err = readEventIssue(os.Getenv("GITHUB_EVENT_PATH"))
+		if err != nil {
+			return err
+		}
+	}
+
+	names, err := github.MatchLabels(m.Labels, issue.Title, issue.Body)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Printf("issue: %s/%s#%d matches no labels\n", t.owner, t.repo, issue.Number)
+		return nil
+	}
+	if opts.dryRun {
+		fmt.Printf("labels: %s will be added to: %s/%s#%d\n", strings.Join(names, ", "), t.owner, t.repo, issue.Number)
+		return nil
+	}
+	if err := client.AddLabels(ctx, t.owner, t.repo, issue.Number, names); err != nil {
+		return fmt.Errorf("unable to add labels: %w", err)
+	}
+	fmt.Printf("labels: %s added to: %s/%s#%d\n", strings.Join(names, ", "), t.owner, t.repo, issue.Number)
+	return nil
+}
+
+// readEventIssue returns the issue or pull request of the event payload at
+// path.
+func readEventIssue(path string) (*eventIssue, error) {
+	if len(path) == 0 {
+		return nil, errors.New("label-issue requires an issue number or an issues or pull_request event")
+	}
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read event: %w", err)
+	}
+	var e issueEvent
+	if err := json.Unmarshal(buf, &e); err != nil {
+		return nil, fmt.Errorf("unable to parse event: %w", err)
+	}
+	switch {
+	case e.Issue != nil:
+		return e.Issue, nil
+	case e.PullRequest != nil:
+		return e.PullRequest, nil
+	default:
+		return nil, errors.New("event has no issue or pull request")
+	}
+}
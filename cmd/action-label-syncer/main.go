@@ -15,11 +15,13 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/micnncim/action-label-syncer/pkg/github"
@@ -33,44 +35,407 @@ func main() {
 }
 
 func run(ctx context.Context) error {
-	manifest := os.Getenv("INPUT_MANIFEST")
-	labels, err := github.FromManifestToLabels(manifest)
+	opts, err := parseOptions(os.Args[1:])
+	if err == flag.ErrHelp {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	stop, err := startCassette(opts)
+	if err != nil {
+		return err
+	}
+	startLimiter(opts)
+	return multierr.Combine(runMode(ctx, opts), stop(), checkWarnings(opts, 0))
+}
+
+// checkWarnings fails with the strict input if any warning was printed since
+// the count of warnings was since, e.g. during one pass of a daemon.
+func checkWarnings(opts *options, since int) error {
+	if n := github.Warnings() - since; opts.strict && n > 0 {
+		return fmt.Errorf("strict: %d warning(s) printed", n)
+	}
+	return nil
+}
+
+func runMode(ctx context.Context, opts *options) error {
+	switch opts.mode {
+	case "sync":
+		return runSync(ctx, opts)
+	case "check":
+		return runCheck(ctx, opts)
+	case "validate":
+		return runValidate(opts)
+	case "validate-config":
+		return runValidateConfig(opts)
+	case "doctor":
+		return runDoctor(ctx, opts)
+	case "fmt":
+		return runFmt(opts)
+	case "colors":
+		return runColors(opts)
+	case "plan":
+		return runPlan(ctx, opts)
+	case "merge":
+		return runMerge(opts)
+	case "summarize":
+		return runSummarize(ctx, opts)
+	case "apply":
+		return runApply(ctx, opts)
+	case "restore":
+		return runRestore(ctx, opts)
+	case "reverse-sync":
+		return runReverseSync(ctx, opts)
+	case "copy":
+		return runCopy(ctx, opts)
+	case "serve":
+		return runServe(ctx, opts)
+	case "command":
+		return runCommand(ctx, opts)
+	case "label-issue":
+		return runLabelIssue(ctx, opts)
+	case "report":
+		return runReport(ctx, opts)
+	case "daemon":
+		return runDaemon(ctx, opts)
+	case "adopt":
+		return runAdopt(ctx, opts)
+	case "merge-duplicates":
+		return runMergeDuplicates(ctx, opts)
+	case "init":
+		return runInit(opts)
+	case "completion":
+		return runCompletion(opts)
+	case "help":
+		return runHelp(opts)
+	default:
+		return fmt.Errorf("unknown mode: %s", opts.mode)
+	}
+}
+
+func runSync(ctx context.Context, opts *options) error {
+	if opts.dryRun {
+		return runPlan(ctx, opts)
+	}
+
+	m, err := loadManifest(opts)
 	if err != nil {
 		return fmt.Errorf("unable to load manifest: %w", err)
 	}
+	if err := checkContrast(opts, m.Labels); err != nil {
+		return err
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	if err := checkSets(targets, m.Labels); err != nil {
+		return err
+	}
+	if err := writeEffectiveConfig(opts, m.Labels, targets); err != nil {
+		return err
+	}
+	if targets, err = includeGenerated(ctx, opts, targets); err != nil {
+		return err
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	return multierr.Append(
+		syncTargets(ctx, opts, provider, targets, m.Labels, m.Milestones),
+		syncProject(ctx, opts, m.Labels, true),
+	)
+}
+
+// loadManifest loads the manifest with its overlays layered on top, the name
+// transforms applied and the descriptions truncated per description-overflow.
+// With overlays, it prints where every label comes from.
+func loadManifest(opts *options) (*github.Manifest, error) {
+	m, origins, err := layerManifest(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.overlays()) > 0 {
+		for _, o := range origins {
+			fmt.Println(o)
+		}
+	}
+	return m, nil
+}
 
-	prune, err := strconv.ParseBool(os.Getenv("INPUT_PRUNE"))
+// layerManifest is loadManifest returning the origin of every label instead
+// of printing them.
+func layerManifest(opts *options) (*github.Manifest, []github.Origin, error) {
+	m, origins, err := github.LayerManifests(opts.manifest, opts.overlays())
 	if err != nil {
-		return fmt.Errorf("unable to parse prune: %w", err)
+		return nil, nil, err
 	}
+	m.Labels = truncateDescriptions(opts, github.TransformNames(m.Labels, opts.transforms))
+	return m, origins, nil
+}
 
-	token := os.Getenv("INPUT_TOKEN")
-	if len(token) == 0 {
-		token = os.Getenv("GITHUB_TOKEN")
+// syncTargets syncs labels, and milestones if not nil, to every target, asking
+// for confirmation and taking backups as configured. With dry-run, it only
+// prints the plans.
+func syncTargets(ctx context.Context, opts *options, provider github.Provider, targets []target, labels []github.Label, milestones []github.Milestone) error {
+	client, ok := provider.(*github.Client)
+	if milestones != nil && !ok {
+		return errMilestonesUnsupported
+	}
+	if len(opts.stamp) > 0 && !ok {
+		return errors.New("stamp is only supported with the github provider")
+	}
+	if len(opts.defaultLabel) > 0 {
+		if !ok {
+			return errors.New("default-label is only supported with the github provider")
+		}
+		// Adding a missing label creates it, only for a prune to delete it.
+		if !hasLabel(labels, opts.defaultLabel) {
+			return fmt.Errorf("default-label %s is missing from the manifest", opts.defaultLabel)
+		}
+	}
+	if err := checkEmptyManifest(opts, labels); err != nil {
+		return err
+	}
+	syncer := newSyncer(opts, provider)
+	st, err := readState(opts)
+	if err != nil {
+		return err
 	}
-	client := github.NewClient(token)
 
-	repository := os.Getenv("INPUT_REPOSITORY")
-	if len(repository) == 0 {
-		repository = os.Getenv("GITHUB_REPOSITORY")
+	var in *bufio.Reader
+	if !opts.yes && isInteractive() {
+		in = bufio.NewReader(os.Stdin)
 	}
 
+	r := &retriage{opts: opts}
+	n := &notifier{opts: opts}
+	s := &stamps{opts: opts, client: client}
 	// Doesn't run concurrently to avoid GitHub API rate limit.
-	for _, r := range strings.Split(repository, "\n") {
-		if len(r) == 0 {
+	for _, t := range targets {
+		synced, e := s.upToDate(ctx, t, labels)
+		if e != nil {
+			github.Warnf("unable to read the stamp of %s/%s: %v", t.owner, t.repo, e)
+		}
+		if synced && milestones == nil {
+			fmt.Printf("labels on: %s/%s already synced with the manifest, skipped\n", t.owner, t.repo)
+			continue
+		}
+		t, e := resolveTarget(ctx, opts, provider, st, t)
+		if e == errSkipped {
+			continue
+		}
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		plan, complete, e := syncTarget(ctx, opts, syncer, st, r, in, t, labels)
+		if e == errAborted {
+			return multierr.Combine(err, e, r.write(), writeState(opts, st), n.send(ctx), n.write())
+		}
+		n.add(t, plan, e)
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		if !opts.dryRun {
+			if e := stamp(ctx, opts, client, t, labels, complete); e != nil {
+				err = multierr.Append(err, e)
+			}
+		}
+		if e := labelUnlabeledIssues(ctx, opts, client, t); e != nil {
+			err = multierr.Append(err, e)
+		}
+		if milestones == nil {
 			continue
 		}
+		if e := syncMilestones(ctx, opts, client, t, milestones); e != nil {
+			err = multierr.Append(err, e)
+		}
+	}
+	if !opts.dryRun {
+		err = multierr.Combine(err, r.write(), writeState(opts, st), n.send(ctx), n.write(), writeBadge(opts, err))
+	}
 
-		s := strings.Split(r, "/")
-		if len(s) != 2 {
-			err = multierr.Append(err, fmt.Errorf("invalid repository: %s", repository))
+	return err
+}
+
+// syncTarget syncs labels to t and returns the plan it has applied, if any,
+// and whether it was applied as planned, without any operation kept or
+// declined.
+func syncTarget(ctx context.Context, opts *options, syncer *github.Syncer, st *github.State, r *retriage, in *bufio.Reader, t target, labels []github.Label) (_ *github.Plan, complete bool, err error) {
+	if !opts.dryRun {
+		unlock, err := syncer.Lock(ctx, t.owner, t.repo)
+		if err != nil {
+			return nil, false, err
 		}
-		owner, repo := s[0], s[1]
+		defer func() {
+			err = multierr.Append(err, unlock())
+		}()
+	}
 
-		if err := client.SyncLabels(ctx, owner, repo, labels, prune); err != nil {
-			err = multierr.Append(err, fmt.Errorf("unable to sync labels: %w", err))
+	labels = t.labels(labels)
+	plan, err := syncer.PlanLabels(ctx, t.owner, t.repo, labels, opts.prune)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to sync labels: %w", err)
+	}
+	warnCollisions(plan, labels)
+	planned := len(plan.Operations)
+	keepUnmanagedLabels(opts, st, plan)
+	keepDefaultLabels(opts, plan)
+	if err := keepNewLabels(ctx, opts, plan); err != nil {
+		return nil, false, err
+	}
+	if err := keepUsedLabels(ctx, opts, plan); err != nil {
+		return nil, false, err
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, false, fmt.Errorf("invalid plan: %w", err)
+	}
+	if err := checkDeletions(opts, plan); err != nil {
+		if opts.dryRun {
+			fmt.Print(plan)
 		}
+		return nil, false, err
+	}
+	if opts.dryRun {
+		fmt.Print(plan)
+		printImpact(ctx, opts, plan)
+		printEstimate(ctx, opts, []*github.Plan{plan})
+		return nil, false, nil
 	}
+	printEstimate(ctx, opts, []*github.Plan{plan})
+	if in != nil {
+		if err := confirmDeletions(plan, in); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := backup(opts, plan); err != nil {
+		return nil, false, err
+	}
+	if err := r.record(ctx, plan); err != nil {
+		return nil, false, err
+	}
+	if err := syncer.ApplyPlan(ctx, plan); err != nil {
+		return plan, false, fmt.Errorf("unable to sync labels: %w", err)
+	}
+	if st != nil {
+		st.Update(plan, labels)
+	}
+	return plan, len(plan.Operations) == planned, nil
+}
+
+func syncMilestones(ctx context.Context, opts *options, client *github.Client, t target, milestones []github.Milestone) error {
+	ops, err := client.PlanMilestones(ctx, t.owner, t.repo, milestones, opts.prune)
+	if err != nil {
+		return fmt.Errorf("unable to sync milestones: %w", err)
+	}
+	if opts.dryRun {
+		for _, op := range ops {
+			fmt.Printf("%s on: %s/%s\n", op, t.owner, t.repo)
+		}
+		return nil
+	}
+	if err := client.ApplyMilestones(ctx, t.owner, t.repo, ops); err != nil {
+		return fmt.Errorf("unable to sync milestones: %w", err)
+	}
+	return nil
+}
 
+type target struct {
+	owner, repo string
+	// locale selects the translated descriptions of the labels.
+	locale string
+	// sets is the comma-separated list of the label sets synced to the
+	// repository. It's kept as a string so that targets stay comparable.
+	sets string
+}
+
+// labels returns labels as they are synced to t.
+func (t target) labels(labels []github.Label) []github.Label {
+	return github.Localize(github.SelectSets(labels, t.labelSets()), t.locale)
+}
+
+// labelSets returns the label sets selected by t.
+func (t target) labelSets() []string {
+	var sets []string
+	for _, s := range strings.Split(t.sets, ",") {
+		if len(s) > 0 {
+			sets = append(sets, s)
+		}
+	}
+	return sets
+}
+
+// checkSets returns an error if targets select sets no label is in.
+func checkSets(targets []target, labels []github.Label) error {
+	known := make(map[string]bool)
+	for _, s := range github.LabelSets(labels) {
+		known[s] = true
+	}
+	var err error
+	for _, t := range targets {
+		for _, s := range t.labelSets() {
+			if !known[s] {
+				err = multierr.Append(err, fmt.Errorf("unknown label set: %s on: %s/%s", s, t.owner, t.repo))
+			}
+		}
+	}
 	return err
 }
+
+// parseTargets parses the newline-separated owner/repo list of the repository
+// input. The owner may contain slashes for GitLab subgroups, e.g.
+// group/subgroup/project. A repository may be followed by space-separated
+// key=value settings: locale=<locale> selects translated descriptions and
+// sets=<set>,<set> the label sets to sync.
+func parseTargets(repository string) ([]target, error) {
+	var (
+		targets []target
+		err     error
+	)
+	for _, line := range strings.Split(repository, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		r := fields[0]
+		i := strings.LastIndex(r, "/")
+		if i <= 0 || i == len(r)-1 {
+			err = multierr.Append(err, fmt.Errorf("invalid repository: %s", r))
+			continue
+		}
+		t := target{owner: r[:i], repo: r[i+1:]}
+		if e := t.parseSettings(fields[1:]); e != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid repository: %s: %w", r, e))
+			continue
+		}
+		targets = append(targets, t)
+	}
+	return targets, err
+}
+
+func (t *target) parseSettings(settings []string) error {
+	for _, s := range settings {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("setting %q must be key=value", s)
+		}
+		switch kv[0] {
+		case "locale":
+			t.locale = kv[1]
+		case "sets":
+			t.sets = kv[1]
+		default:
+			return fmt.Errorf("unknown setting: %s", kv[0])
+		}
+	}
+	return nil
+}
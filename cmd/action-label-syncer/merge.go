@@ -0,0 +1,98 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runMerge combines the plan files written by the legs of a matrix into one
+// report for reviewers, printed and added to the job summary, and, if a plan
+// file is given, into one plan to apply.
+func runMerge(opts *options) error {
+	var paths []string
+	for _, pattern := range strings.Split(opts.plans, "\n") {
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid plans pattern: %s: %w", pattern, err)
+		}
+		paths = append(paths, matches...)
+	}
+	paths = append(paths, opts.args...)
+	if len(paths) == 0 {
+		return errors.New("merge requires plan files")
+	}
+	sort.Strings(paths)
+
+	var plans []*github.Plan
+	seen := make(map[string]string)
+	for _, path := range paths {
+		ps, err := github.ReadPlans(path)
+		if err != nil {
+			return fmt.Errorf("unable to read plan: %s: %w", path, err)
+		}
+		for _, p := range ps {
+			key := p.Owner + "/" + p.Repo
+			if prev, ok := seen[key]; ok {
+				return fmt.Errorf("%s is planned by both %s and %s", key, prev, path)
+			}
+			seen[key] = path
+			plans = append(plans, p)
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Owner+"/"+plans[i].Repo < plans[j].Owner+"/"+plans[j].Repo
+	})
+
+	report := github.MarkdownReport(plans)
+	fmt.Print(report)
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); len(path) > 0 {
+		if err := appendFile(path, report); err != nil {
+			return fmt.Errorf("unable to write job summary: %w", err)
+		}
+	}
+
+	if len(opts.plan) == 0 {
+		return nil
+	}
+	if err := github.WritePlans(opts.plan, plans); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+	fmt.Printf("plan: %d plan file(s) merged into %s\n", len(paths), opts.plan)
+	return nil
+}
+
+func appendFile(path, s string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
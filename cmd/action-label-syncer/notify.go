@@ -0,0 +1,74 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"github.com/micnncim/action-label-syncer/pkg/notify"
+)
+
+// notifier collects the results of syncs to post them to the notify-url input
+// and write them to the result-file input, if set.
+type notifier struct {
+	opts    *options
+	results []notify.Result
+}
+
+// add records the result of syncing t. plan may be nil if planning failed.
+func (n *notifier) add(t target, plan *github.Plan, err error) {
+	r := notify.Result{Repository: t.owner + "/" + t.repo}
+	if plan != nil {
+		r.Operations = plan.Operations
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	n.results = append(n.results, r)
+}
+
+// send posts the results if anything has changed or failed.
+func (n *notifier) send(ctx context.Context) error {
+	if len(n.opts.notifyURL) == 0 {
+		return nil
+	}
+	changed := false
+	for _, r := range n.results {
+		if len(r.Operations) > 0 || len(r.Error) > 0 {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := notify.Send(ctx, n.opts.notifyURL, n.opts.notifyFormat, n.results); err != nil {
+		return fmt.Errorf("unable to notify: %w", err)
+	}
+	return nil
+}
+
+// write writes the results to the result-file input, if set, for summarize.
+func (n *notifier) write() error {
+	if len(n.opts.resultFile) == 0 {
+		return nil
+	}
+	if err := notify.WriteResults(n.opts.resultFile, n.results); err != nil {
+		return fmt.Errorf("unable to write results: %w", err)
+	}
+	return nil
+}
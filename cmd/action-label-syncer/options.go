@@ -0,0 +1,480 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"github.com/micnncim/action-label-syncer/pkg/gitlab"
+	"go.uber.org/multierr"
+)
+
+const command = "action-label-syncer"
+
+// modes lists the modes the command runs in, in the order shown by help.
+var modes = []struct {
+	name, usage string
+}{
+	{"sync", "sync labels with the manifest (default)"},
+	{"plan", "print and optionally save the changes sync would make"},
+	{"apply", "apply a saved plan"},
+	{"merge", "merge plans of matrix jobs into one plan and report"},
+	{"summarize", "combine the results of matrix jobs into one summary and exit status"},
+	{"restore", "restore labels from a backup"},
+	{"copy", "copy labels from one repository to others"},
+	{"reverse-sync", "propose a manifest update matching the repository labels"},
+	{"serve", "re-apply the manifest on label and repository webhooks"},
+	{"command", "run a /sync-labels pull request comment and reply with the outcome"},
+	{"label-issue", "add the labels whose match rules match an issue"},
+	{"report", "write the issue usage of every label as CSV or JSON"},
+	{"daemon", "sync on a cron schedule with health and readiness endpoints"},
+	{"adopt", "record the labels in the manifest as managed in the state file"},
+	{"merge-duplicates", "merge labels differing only by case or punctuation"},
+	{"check", "fail on manifest problems, missing template labels or drift"},
+	{"validate", "validate the manifest without calling the GitHub API"},
+	{"doctor", "check API access, the token, its scopes, repository permissions and the rate limit"},
+	{"validate-config", "validate the config file and every profile without calling the GitHub API"},
+	{"fmt", "rewrite the manifest in its canonical form"},
+	{"colors", "report and optionally fix non-canonical manifest colors"},
+	{"init", "write a starter manifest from a template"},
+	{"completion", "print a bash, zsh or fish completion script"},
+	{"help", "print this help, or as JSON with `help json`"},
+}
+
+// options holds the inputs of the action. Every input can be given either as
+// a command-line flag or, as GitHub Actions does, through the INPUT_<NAME>
+// environment variable, or in a config file. Flags take precedence over
+// environment variables, which take precedence over the config file.
+type options struct {
+	mode string
+	args []string
+	// inputs are the flags bound to the options, to list their values.
+	inputs              *flag.FlagSet
+	config              string
+	profile             string
+	manifest            string
+	manifestOverlays    string
+	effectiveConfig     string
+	snapshot            string
+	repository          string
+	includeGenerated    bool
+	token               string
+	tokenFile           string
+	provider            string
+	githubURL           string
+	gitlabURL           string
+	giteaURL            string
+	nameTransforms      string
+	transforms          []github.NameTransform
+	prune               bool
+	reserved            string
+	maxLabels           int
+	namePattern         string
+	naming              *github.NamingPolicy
+	allowEmptyManifest  bool
+	keepUsed            bool
+	keepDefaultLabels   bool
+	graceDays           int
+	maxDeletions        int
+	force               bool
+	onConflict          string
+	descriptionOverflow string
+	rollbackOnFailure   bool
+	missingRepoPolicy   string
+	enforce             string
+	fields              github.Fields
+	operations          string
+	operationKinds      []github.OperationKind
+	retries             int
+	concurrency         int
+	requestRate         float64
+	chunkSize           int
+	lockLabel           string
+	lockTimeout         time.Duration
+	callTimeout         time.Duration
+	slowCall            time.Duration
+	state               string
+	strictAccessibility bool
+	strictDescriptions  bool
+	strict              bool
+	dryRun              bool
+	verbose             bool
+	yes                 bool
+	watch               bool
+	plan                string
+	plans               string
+	results             string
+	runID               int64
+	artifactPattern     string
+	resultFile          string
+	backup              string
+	backupDir           string
+	backupFormat        string
+	retriageFile        string
+	badgeFile           string
+	stamp               string
+	stampName           string
+	skipStamped         bool
+	defaultLabel        string
+	record              string
+	replay              string
+	notifyURL           string
+	notifyFormat        string
+	from                string
+	to                  string
+	configRepository    string
+	reverseSyncBranch   string
+	addr                string
+	webhookSecret       string
+	schedule            string
+	githubDir           string
+	checkLinks          bool
+	driftBaseline       string
+	updateBaseline      bool
+	template            string
+	check               bool
+	fix                 bool
+	sort                string
+	reportFile          string
+	reportFormat        string
+	staleMonths         int
+	issue               int
+	project             string
+	projectField        string
+	projectGroup        string
+}
+
+// modeDefaults are the defaults of the inputs that differ by mode. Their
+// inputs have no default in action.yml, which would override them.
+var modeDefaults = map[string]map[string]string{
+	// copy only deletes the labels missing from the source when asked to.
+	"copy": {"prune": "false"},
+	// colors --fix keeps the layout of the manifest unless asked to sort it.
+	"colors": {"sort": ""},
+}
+
+func parseOptions(args []string) (*options, error) {
+	opts := &options{
+		mode: os.Getenv("INPUT_MODE"),
+	}
+	if len(opts.mode) == 0 {
+		opts.mode = os.Getenv(envName("mode"))
+	}
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.mode, args = args[0], args[1:]
+	}
+	if len(opts.mode) == 0 {
+		opts.mode = "sync"
+	}
+
+	fs := newFlagSet(opts)
+	for name, v := range modeDefaults[opts.mode] {
+		f := fs.Lookup(name)
+		f.DefValue = v
+		_ = f.Value.Set(v)
+	}
+	// validate-config reports the problems of the config itself rather than
+	// failing to apply it.
+	if err := parseInputs(fs, args, opts.mode != "validate-config"); err != nil {
+		return nil, err
+	}
+	opts.args = fs.Args()
+	opts.inputs = fs
+
+	if err := opts.parsePolicies(); err != nil {
+		return nil, err
+	}
+
+	if len(opts.token) == 0 && len(opts.tokenFile) > 0 {
+		buf, err := ioutil.ReadFile(opts.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read token file: %w", err)
+		}
+		opts.token = strings.TrimSpace(string(buf))
+	}
+	if len(opts.token) == 0 && opts.provider == "gitlab" {
+		opts.token = os.Getenv("GITLAB_TOKEN")
+	}
+	if len(opts.token) == 0 && opts.provider == "gitea" {
+		opts.token = os.Getenv("GITEA_TOKEN")
+	}
+	if len(opts.token) == 0 {
+		opts.token = os.Getenv("GITHUB_TOKEN")
+	}
+	if len(opts.manifest) == 0 {
+		opts.manifest = findManifest()
+	}
+	if len(opts.repository) == 0 {
+		opts.repository = currentRepository()
+	}
+	if len(opts.configRepository) == 0 {
+		opts.configRepository = currentRepository()
+	}
+	return opts, nil
+}
+
+// parsePolicies checks the inputs choosing between behaviors and parses the
+// name transforms, enforced fields and planned operations.
+func (o *options) parsePolicies() error {
+	var err error
+	if o.onConflict != "update" && o.onConflict != "fail" {
+		err = multierr.Append(err, fmt.Errorf("unknown on-conflict: %s", o.onConflict))
+	}
+	switch o.descriptionOverflow {
+	case "fail", "truncate", "warn-truncate":
+	default:
+		err = multierr.Append(err, fmt.Errorf("unknown description-overflow: %s", o.descriptionOverflow))
+	}
+	if o.missingRepoPolicy != "skip" && o.missingRepoPolicy != "fail" {
+		err = multierr.Append(err, fmt.Errorf("unknown missing-repo-policy: %s", o.missingRepoPolicy))
+	}
+	if o.stamp != "" && o.stamp != "topic" && o.stamp != "property" {
+		err = multierr.Append(err, fmt.Errorf("unknown stamp: %s", o.stamp))
+	}
+	if o.skipStamped && len(o.stamp) == 0 {
+		err = multierr.Append(err, errors.New("skip-stamped requires stamp"))
+	}
+	for _, spec := range strings.Split(o.nameTransforms, "\n") {
+		if spec = strings.TrimSpace(spec); len(spec) == 0 {
+			continue
+		}
+		t, e := github.ParseNameTransform(spec)
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		o.transforms = append(o.transforms, t)
+	}
+	o.naming = &github.NamingPolicy{MaxLabels: o.maxLabels}
+	if len(o.namePattern) > 0 {
+		re, e := regexp.Compile(o.namePattern)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid name-pattern: %w", e))
+		}
+		o.naming.NamePattern = re
+	}
+	fields, e := github.ParseFields(o.enforce)
+	if e != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid enforce: %w", e))
+	}
+	o.fields = fields
+	kinds, e := github.ParseOperations(o.operations)
+	if e != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid operations: %w", e))
+	}
+	o.operationKinds = kinds
+	return err
+}
+
+// envName returns the environment variable setting the input name outside
+// GitHub Actions, e.g. LABEL_SYNCER_DRY_RUN for dry-run, as other CI systems
+// and container runtimes don't allow hyphens in variable names.
+func envName(name string) string {
+	return "LABEL_SYNCER_" + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// reservedPrefixes returns the prefixes of the reserved-prefixes input.
+func (o *options) reservedPrefixes() []string {
+	var prefixes []string
+	for _, p := range strings.FieldsFunc(o.reserved, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
+}
+
+// overlays returns the manifests of the manifest-overlays input.
+func (o *options) overlays() []string {
+	var overlays []string
+	for _, line := range strings.Split(o.manifestOverlays, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			overlays = append(overlays, line)
+		}
+	}
+	return overlays
+}
+
+// manifestPaths are the paths searched for the manifest, in order, when the
+// manifest input isn't set.
+var manifestPaths = []string{".github/labels.yml", ".github/labels.yaml", "labels.yml"}
+
+// findManifest returns the first of manifestPaths that exists, or the first
+// one to report missing or to create with init.
+func findManifest() string {
+	for _, path := range manifestPaths {
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() {
+			return path
+		}
+	}
+	return manifestPaths[0]
+}
+
+// currentRepository returns the repository the action runs for or, outside
+// GitHub Actions, the one of the origin remote of the working directory.
+func currentRepository() string {
+	if r := os.Getenv("GITHUB_REPOSITORY"); len(r) > 0 {
+		return r
+	}
+	return gitRemoteRepository()
+}
+
+// newFlagSet returns the flag set of every input, storing the values into opts.
+func newFlagSet(opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.Usage = func() {
+		printUsage(fs)
+	}
+	fs.StringVar(&opts.config, "config", "", "YAML file of inputs, optionally with labels and profiles")
+	fs.StringVar(&opts.profile, "profile", "", "profile of the config file to apply")
+	fs.StringVar(&opts.manifest, "manifest", "", "file path of YAML manifest for labels (defaults to the first of .github/labels.yml, .github/labels.yaml and labels.yml that exists)")
+	fs.StringVar(&opts.effectiveConfig, "effective-config", "", "file to write the resolved inputs and labels to as YAML, or - to print them, to debug which labels are managed")
+	fs.StringVar(&opts.manifestOverlays, "manifest-overlays", "", "newline-separated manifests whose labels override the labels of the manifest by name, in order")
+	fs.StringVar(&opts.snapshot, "snapshot", "", "plan against the labels in this file, e.g. a backup, instead of calling the API")
+	fs.StringVar(&opts.repository, "repository", "", "newline-separated repositories to sync labels on (defaults to $GITHUB_REPOSITORY, then the origin remote)")
+	fs.BoolVar(&opts.includeGenerated, "include-generated", false, "also sync the repositories generated from template repositories among repository")
+	fs.StringVar(&opts.token, "token", "", "token of the provider (defaults to $GITLAB_TOKEN for gitlab or $GITEA_TOKEN for gitea, then $GITHUB_TOKEN); several github tokens, one per line, are rotated")
+	fs.StringVar(&opts.tokenFile, "token-file", "", "file to read the token from if token isn't set, e.g. a mounted secret")
+	fs.StringVar(&opts.provider, "provider", "github", "where the repositories are hosted: github, gitlab or gitea")
+	fs.StringVar(&opts.githubURL, "github-url", "", "URL of the GitHub server, e.g. https://octocorp.ghe.com, defaulting to GITHUB_SERVER_URL")
+	fs.StringVar(&opts.gitlabURL, "gitlab-url", gitlab.DefaultURL, "URL of the GitLab instance")
+	fs.StringVar(&opts.giteaURL, "gitea-url", "", "URL of the Gitea or Forgejo instance")
+	fs.StringVar(&opts.nameTransforms, "name-transforms", "", "newline-separated transforms applied to manifest names in order: lowercase, kebab-case or prefix:<prefix>")
+	fs.BoolVar(&opts.prune, "prune", true, "remove unmanaged labels from repository (default false for copy)")
+	fs.IntVar(&opts.maxLabels, "max-labels", 0, "validate, check: maximum number of labels of the manifest and of each repository (0 for no limit)")
+	fs.StringVar(&opts.namePattern, "name-pattern", "", "validate, check: regular expression every label name of the manifest and of each repository must match, e.g. ^[a-z0-9/_-]+$")
+	fs.StringVar(&opts.reserved, "reserved-prefixes", "", "newline- or comma-separated name prefixes of labels never touched and not allowed in the manifest, or description:<text> and color:<color> rules")
+	fs.BoolVar(&opts.allowEmptyManifest, "allow-empty-manifest", false, "sync a manifest with no labels even with prune, deleting every label")
+	fs.BoolVar(&opts.keepUsed, "keep-used", false, "don't remove labels used by issues, pull requests or discussions")
+	fs.BoolVar(&opts.keepDefaultLabels, "keep-default-labels", false, "don't remove GitHub's default labels such as bug and documentation")
+	fs.IntVar(&opts.graceDays, "grace-days", 0, "don't remove labels created within this many days (0 to remove them right away)")
+	fs.IntVar(&opts.maxDeletions, "max-deletions", 10, "refuse to delete more labels from a repository than this without force (-1 for no limit)")
+	fs.BoolVar(&opts.force, "force", false, "delete labels beyond max-deletions")
+	fs.StringVar(&opts.descriptionOverflow, "description-overflow", "fail", "what to do with descriptions longer than 100 characters: fail, truncate or warn-truncate")
+	fs.StringVar(&opts.onConflict, "on-conflict", "update", "what to do when a label to create already exists: update or fail")
+	fs.BoolVar(&opts.rollbackOnFailure, "rollback-on-failure", false, "revert the changes already made to a repository when syncing it fails midway")
+	fs.StringVar(&opts.missingRepoPolicy, "missing-repo-policy", "fail", "what to do when a repository doesn't exist: skip or fail")
+	fs.StringVar(&opts.operations, "operations", "all", "operations to plan: all, or a comma-separated list of create, update, delete and rename")
+	fs.StringVar(&opts.enforce, "enforce", "all", "fields enforced on existing labels: all, or a comma-separated list of name, color and description")
+	fs.IntVar(&opts.retries, "retries", 3, "times to retry operations failing with server, network or secondary rate limit errors")
+	fs.IntVar(&opts.concurrency, "concurrency", 0, "maximum number of labels changed at the same time, in manifest order (0 for no limit)")
+	fs.IntVar(&opts.chunkSize, "chunk-size", 0, "apply changes in chunks of this many operations with a checkpoint after each (0 to apply them at once)")
+	fs.Float64Var(&opts.requestRate, "request-rate", 0, "maximum API requests per second across every repository of the run (0 for no limit)")
+	fs.StringVar(&opts.lockLabel, "lock-label", "", "marker label locking repositories against concurrent syncs (no locking if empty)")
+	fs.DurationVar(&opts.lockTimeout, "lock-timeout", 5*time.Minute, "how long to wait for a lock held by another sync")
+	fs.DurationVar(&opts.callTimeout, "call-timeout", time.Minute, "how long a single API call may take before it fails (0 for no limit)")
+	fs.DurationVar(&opts.slowCall, "slow-call", 10*time.Second, "warn about API calls taking longer than this (0 to disable)")
+	fs.StringVar(&opts.state, "state", "", "file recording the managed labels; with it, only managed labels are removed")
+	fs.BoolVar(&opts.strictAccessibility, "strict-accessibility", false, "fail instead of warning when label colors make names hard to read")
+	fs.BoolVar(&opts.strict, "strict", false, "fail the run if any warning was printed, after it has finished")
+	fs.BoolVar(&opts.strictDescriptions, "strict-descriptions", false, "fail instead of warning on descriptions GitHub would alter, e.g. with line breaks, or show as is, e.g. with HTML")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "print the planned changes without applying them")
+	fs.BoolVar(&opts.verbose, "verbose", false, "print every label kept or left alone rather than only how many")
+	fs.BoolVar(&opts.yes, "yes", false, "delete labels without asking for confirmation on a terminal")
+	fs.BoolVar(&opts.watch, "watch", false, "plan: print the plan again whenever the manifest changes")
+	fs.StringVar(&opts.plan, "plan", "", "plan, merge: file to write the plan to; apply: file to read the plan from")
+	fs.StringVar(&opts.plans, "plans", "", "merge: newline-separated plan files or glob patterns to merge")
+	fs.StringVar(&opts.resultFile, "result-file", "", "file to write the results of the sync to as JSON, e.g. for summarize to combine the legs of a matrix")
+	fs.StringVar(&opts.results, "results", "", "summarize: newline-separated result files or glob patterns (defaults to the artifacts of the workflow run)")
+	fs.Int64Var(&opts.runID, "run-id", 0, "summarize: workflow run to download result artifacts from (defaults to $GITHUB_RUN_ID)")
+	fs.StringVar(&opts.artifactPattern, "artifact-pattern", "label-results*", "summarize: pattern of the names of the artifacts holding result files")
+	fs.StringVar(&opts.backup, "backup", "", "restore: backup file to restore labels from")
+	fs.StringVar(&opts.backupDir, "backup-dir", "", "directory to back up labels to before deleting or updating them")
+	fs.StringVar(&opts.backupFormat, "backup-format", "yaml", "format of backups: yaml or json")
+	fs.StringVar(&opts.retriageFile, "retriage-file", "", "CSV file to list the open issues losing a deleted or renamed label in")
+	fs.StringVar(&opts.record, "record", "", "record the HTTP interactions of the run to this cassette file, e.g. for bug reports")
+	fs.StringVar(&opts.replay, "replay", "", "answer HTTP requests from this cassette file instead of the network")
+	fs.StringVar(&opts.badgeFile, "badge-file", "", "file to write the sync status to as a shields.io endpoint badge")
+	fs.StringVar(&opts.stamp, "stamp", "", "after syncing a repository, record a hash of its labels as a topic or a custom property: topic or property (none if empty)")
+	fs.StringVar(&opts.defaultLabel, "default-label", "", "after syncing a repository, add this label to its open issues without any label, e.g. needs-triage")
+	fs.BoolVar(&opts.skipStamped, "skip-stamped", false, "skip repositories already stamped with the hash of the labels")
+	fs.StringVar(&opts.stampName, "stamp-name", "labels-synced", "name of the custom property, or prefix of the topic, recording the hash of the labels")
+	fs.StringVar(&opts.notifyURL, "notify-url", "", "webhook to post the changes and errors of syncs to")
+	fs.StringVar(&opts.notifyFormat, "notify-format", "json", "format of notifications: json or slack")
+	fs.StringVar(&opts.from, "from", "", "copy: repository to copy labels from")
+	fs.StringVar(&opts.to, "to", "", "copy: newline-separated repositories to copy labels to")
+	fs.StringVar(&opts.configRepository, "config-repository", "", "reverse-sync: repository holding the manifest (defaults to $GITHUB_REPOSITORY, then the origin remote)")
+	fs.StringVar(&opts.reverseSyncBranch, "reverse-sync-branch", "label-syncer/reverse-sync", "reverse-sync: branch to propose manifest updates from")
+	fs.StringVar(&opts.addr, "addr", ":8080", "serve, daemon: address to listen on")
+	fs.StringVar(&opts.webhookSecret, "webhook-secret", "", "serve: secret of the webhook to verify deliveries with (required)")
+	fs.StringVar(&opts.schedule, "schedule", "0 * * * *", "daemon: cron expression of when to sync")
+	fs.StringVar(&opts.githubDir, "github-dir", ".github", "check: directory holding ISSUE_TEMPLATE and labeler.yml")
+	fs.BoolVar(&opts.checkLinks, "check-links", false, "check: fail on URLs in descriptions not responding with 2xx")
+	fs.StringVar(&opts.driftBaseline, "drift-baseline", ".label-drift-baseline.yml", "check: file listing accepted drift, only new drift fails")
+	fs.BoolVar(&opts.updateBaseline, "update-baseline", false, "check: accept the current drift by writing it to drift-baseline")
+	fs.StringVar(&opts.template, "template", "minimal", "init: template of the manifest: "+strings.Join(github.TemplateNames(), ", "))
+	fs.BoolVar(&opts.check, "check", false, "fmt: fail if the manifest isn't formatted instead of rewriting it")
+	fs.BoolVar(&opts.fix, "fix", false, "colors: rewrite the manifest with the colors normalized")
+	fs.StringVar(&opts.sort, "sort", "name", "fmt, colors: sort labels by name or group (colors: none, fixing colors in place)")
+	fs.StringVar(&opts.reportFile, "report-file", "", "report: file to write the report to (defaults to stdout)")
+	fs.StringVar(&opts.reportFormat, "report-format", "csv", "report: format of the report: csv or json")
+	fs.IntVar(&opts.staleMonths, "stale-months", 0, "report: only report managed labels not used by anything updated in this many months (0 to report every label)")
+	fs.StringVar(&opts.project, "project", "", "owner/number of a project to sync a single-select field of with a label group")
+	fs.StringVar(&opts.projectField, "project-field", "Priority", "single-select field of the project to sync")
+	fs.StringVar(&opts.projectGroup, "project-group", "", "label group to sync the project field options with")
+	fs.IntVar(&opts.issue, "issue", 0, "label-issue: number of the issue to label (defaults to the issue of the event)")
+	return fs
+}
+
+// parseInputs parses args into fs and then fills every flag not given on the
+// command line from its INPUT_<NAME> environment variable, or else its
+// LABEL_SYNCER_<NAME> one, and the remaining ones from the config file, if any
+// and config is true.
+func parseInputs(fs *flag.FlagSet, args []string, config bool) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if set[f.Name] {
+			return
+		}
+		v := os.Getenv("INPUT_" + strings.ToUpper(f.Name))
+		if len(v) == 0 {
+			v = os.Getenv(envName(f.Name))
+			if len(v) == 0 {
+				return
+			}
+			// Unlike INPUT_<NAME>, it is only set on purpose.
+			set[f.Name] = true
+		}
+		if e := fs.Set(f.Name, v); e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to parse %s: %w", f.Name, e))
+		}
+		// GitHub Actions passes the default of every input declared in
+		// action.yml, which must not shadow the config.
+		if v != f.DefValue {
+			set[f.Name] = true
+		}
+	})
+	if err != nil || !config {
+		return err
+	}
+	return applyConfig(fs, set)
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// truncateDescriptions returns labels with the descriptions too long truncated
+// unless description-overflow is fail, in which case they fail validation.
+func truncateDescriptions(opts *options, labels []github.Label) []github.Label {
+	if opts.descriptionOverflow == "fail" {
+		return labels
+	}
+	labels, truncations := github.TruncateDescriptions(labels)
+	printTruncations(opts, truncations)
+	return labels
+}
+
+func printTruncations(opts *options, truncations []github.Truncation) {
+	for _, t := range truncations {
+		if opts.descriptionOverflow == "warn-truncate" {
+			github.Warnf("%s", t)
+		} else {
+			fmt.Println(t)
+		}
+	}
+}
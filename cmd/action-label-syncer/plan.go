@@ -0,0 +1,216 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// runPlan prints the operations needed to sync every target and, if a plan
+// file is given, writes them to it for a later apply. With watch, it keeps
+// printing them whenever the manifest changes instead.
+func runPlan(ctx context.Context, opts *options) error {
+	if opts.watch {
+		return runWatch(ctx, opts)
+	}
+
+	m, err := loadManifest(opts)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	if err := checkContrast(opts, m.Labels); err != nil {
+		return err
+	}
+	if err := checkEmptyManifest(opts, m.Labels); err != nil {
+		return err
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	if err := checkSets(targets, m.Labels); err != nil {
+		return err
+	}
+	if err := writeEffectiveConfig(opts, m.Labels, targets); err != nil {
+		return err
+	}
+	if targets, err = includeGenerated(ctx, opts, targets); err != nil {
+		return err
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+	syncer := newSyncer(opts, provider)
+	st, err := readState(opts)
+	if err != nil {
+		return err
+	}
+
+	var plans []*github.Plan
+	for _, t := range targets {
+		t, e := resolveTarget(ctx, opts, provider, st, t)
+		if e == errSkipped {
+			continue
+		}
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		plan, e := syncer.PlanLabels(ctx, t.owner, t.repo, t.labels(m.Labels), opts.prune)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to plan labels: %w", e))
+			continue
+		}
+		warnCollisions(plan, m.Labels)
+		keepUnmanagedLabels(opts, st, plan)
+		keepDefaultLabels(opts, plan)
+		if e := keepNewLabels(ctx, opts, plan); e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		if e := keepUsedLabels(ctx, opts, plan); e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		fmt.Print(plan)
+		printImpact(ctx, opts, plan)
+		if e := plan.Validate(); e != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid plan: %w", e))
+			continue
+		}
+		if e := checkDeletions(opts, plan); e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		plans = append(plans, plan)
+
+		if m.Milestones == nil {
+			continue
+		}
+		client, ok := provider.(*github.Client)
+		if !ok {
+			err = multierr.Append(err, errMilestonesUnsupported)
+			continue
+		}
+		// Milestones aren't part of plan files; they're printed for review only.
+		ops, e := client.PlanMilestones(ctx, t.owner, t.repo, m.Milestones, opts.prune)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to plan milestones: %w", e))
+			continue
+		}
+		for _, op := range ops {
+			fmt.Printf("%s on: %s/%s\n", op, t.owner, t.repo)
+		}
+	}
+	printEstimate(ctx, opts, plans)
+	// Like milestones, project fields are printed for review only.
+	err = multierr.Append(err, syncProject(ctx, opts, m.Labels, false))
+	if err != nil {
+		return err
+	}
+
+	if len(opts.plan) == 0 {
+		return nil
+	}
+	if err := github.WritePlans(opts.plan, plans); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+	fmt.Printf("plan: written to %s\n", opts.plan)
+	return nil
+}
+
+// runApply executes the plan file written by runPlan. A repository whose
+// labels have changed since the plan was made is left untouched.
+func runApply(ctx context.Context, opts *options) error {
+	if len(opts.plan) == 0 {
+		return errors.New("apply requires a plan file")
+	}
+	plans, err := github.ReadPlans(opts.plan)
+	if err != nil {
+		return fmt.Errorf("unable to read plan: %w", err)
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+	syncer := newSyncer(opts, provider)
+
+	st, err := readState(opts)
+	if err != nil {
+		return err
+	}
+	printEstimate(ctx, opts, plans)
+
+	var in *bufio.Reader
+	if !opts.yes && isInteractive() {
+		in = bufio.NewReader(os.Stdin)
+	}
+
+	r := &retriage{opts: opts}
+	for _, plan := range plans {
+		e := applyPlan(ctx, opts, syncer, st, r, in, plan)
+		err = multierr.Append(err, e)
+		if e == errAborted {
+			break
+		}
+	}
+	return multierr.Combine(err, r.write(), writeState(opts, st))
+}
+
+func applyPlan(ctx context.Context, opts *options, syncer *github.Syncer, st *github.State, r *retriage, in *bufio.Reader, plan *github.Plan) (err error) {
+	unlock, err := syncer.Lock(ctx, plan.Owner, plan.Repo)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, unlock())
+	}()
+
+	if err := syncer.VerifyPlan(ctx, plan); err != nil {
+		return fmt.Errorf("refusing to apply plan: %w", err)
+	}
+	if err := checkDeletions(opts, plan); err != nil {
+		return err
+	}
+	if in != nil {
+		if err := confirmDeletions(plan, in); err != nil {
+			return err
+		}
+	}
+	if err := backup(opts, plan); err != nil {
+		return err
+	}
+	if err := r.record(ctx, plan); err != nil {
+		return err
+	}
+	if err := syncer.ApplyPlan(ctx, plan); err != nil {
+		return fmt.Errorf("unable to sync labels: %w", err)
+	}
+	if st != nil {
+		st.Update(plan, nil)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// syncProject syncs the options of the single-select field of the project
+// input with the labels of the project-group input. Without apply, it only
+// prints the options the field would get.
+func syncProject(ctx context.Context, opts *options, labels []github.Label, apply bool) error {
+	if len(opts.project) == 0 {
+		return nil
+	}
+	if len(opts.projectGroup) == 0 {
+		return errors.New("project requires project-group")
+	}
+	i := strings.LastIndex(opts.project, "/")
+	number, err := strconv.Atoi(opts.project[i+1:])
+	if i <= 0 || err != nil {
+		return fmt.Errorf("invalid project: %s", opts.project)
+	}
+	owner := opts.project[:i]
+
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+	field, err := client.GetProjectField(ctx, owner, number, opts.projectField)
+	if err != nil {
+		return fmt.Errorf("unable to get project field: %w", err)
+	}
+	options := github.ProjectOptions(field, labels, opts.projectGroup)
+	if len(options) == 0 {
+		return fmt.Errorf("no labels in group: %s", opts.projectGroup)
+	}
+	if github.EqualProjectOptions(field.Options, options) {
+		return nil
+	}
+
+	names := make([]string, 0, len(options))
+	for _, o := range options {
+		names = append(names, o.Name)
+	}
+	if !apply {
+		fmt.Printf("field: %s options will be set to %s on project: %s\n", opts.projectField, strings.Join(names, ", "), opts.project)
+		return nil
+	}
+	if err := client.UpdateProjectField(ctx, field, options); err != nil {
+		return fmt.Errorf("unable to update project field: %w", err)
+	}
+	fmt.Printf("field: %s options set to %s on project: %s\n", opts.projectField, strings.Join(names, ", "), opts.project)
+	return nil
+}
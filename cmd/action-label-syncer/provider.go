@@ -0,0 +1,164 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/gitea"
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"github.com/micnncim/action-label-syncer/pkg/gitlab"
+)
+
+var (
+	errMilestonesUnsupported = errors.New("milestones are only supported with the github provider")
+	errSkipped               = errors.New("skipped")
+)
+
+// resolveTarget returns t under the current name of its repository, which
+// differs once the repository has been renamed or transferred. GitHub only
+// redirects some requests to the old name, so labels are changed under the
+// current one. The managed labels of the old name are moved in st, if any.
+// A missing repository is skipped with errSkipped if the missing-repo-policy
+// input is skip.
+func resolveTarget(ctx context.Context, opts *options, provider github.Provider, st *github.State, t target) (target, error) {
+	g, ok := provider.(github.RepositoryGetter)
+	if !ok {
+		return t, nil
+	}
+	r, err := g.GetRepository(ctx, t.owner, t.repo)
+	if errors.Is(err, github.ErrNotFound) && opts.missingRepoPolicy == "skip" {
+		github.Warnf("repository: %s/%s not found, skipped", t.owner, t.repo)
+		return t, errSkipped
+	}
+	if err != nil {
+		return t, fmt.Errorf("unable to get repository: %w", err)
+	}
+	if strings.EqualFold(r.Owner, t.owner) && strings.EqualFold(r.Name, t.repo) {
+		return t, nil
+	}
+	fmt.Printf("repository: %s/%s moved to: %s/%s\n", t.owner, t.repo, r.Owner, r.Name)
+	st.Move(t.owner+"/"+t.repo, r.Owner+"/"+r.Name)
+	t.owner, t.repo = r.Owner, r.Name
+	return t, nil
+}
+
+// newProvider returns the label provider selected by the provider input, or
+// the snapshot input if set.
+func newProvider(opts *options) (github.Provider, error) {
+	if len(opts.snapshot) > 0 {
+		s, err := github.LoadSnapshot(opts.snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load snapshot: %w", err)
+		}
+		return s, nil
+	}
+	if opts.provider != "github" && len(splitTokens(opts.token)) > 1 {
+		return nil, errors.New("several tokens are only supported with the github provider")
+	}
+	switch opts.provider {
+	case "github":
+		return newServerClient(opts)
+	case "gitlab":
+		return gitlab.NewClient(opts.gitlabURL, opts.token), nil
+	case "gitea":
+		if len(opts.giteaURL) == 0 {
+			return nil, errors.New("gitea provider requires gitea-url")
+		}
+		return gitea.NewClient(opts.giteaURL, opts.token), nil
+	default:
+		return nil, fmt.Errorf("unknown provider: %s", opts.provider)
+	}
+}
+
+// newGitHubClient returns the GitHub client for modes relying on features
+// only GitHub has, failing for other providers.
+func newGitHubClient(opts *options) (*github.Client, error) {
+	if opts.provider != "github" {
+		return nil, fmt.Errorf("%s mode is only supported with the github provider", opts.mode)
+	}
+	if len(opts.snapshot) > 0 {
+		return nil, fmt.Errorf("%s mode calls the GitHub API, which a snapshot doesn't allow", opts.mode)
+	}
+	return newServerClient(opts)
+}
+
+// newServerClient returns the client of the GitHub server at github-url,
+// defaulting to the server running the workflow.
+func newServerClient(opts *options) (*github.Client, error) {
+	serverURL := opts.githubURL
+	if len(serverURL) == 0 {
+		serverURL = os.Getenv("GITHUB_SERVER_URL")
+	}
+	var (
+		client *github.Client
+		err    error
+	)
+	if tokens := splitTokens(opts.token); len(tokens) > 1 {
+		client, err = github.NewRotatingClient(serverURL, tokens)
+	} else {
+		client, err = github.NewServerClient(serverURL, opts.token)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GitHub client: %w", err)
+	}
+	return client, nil
+}
+
+// newSyncer returns the syncer of provider configured by the inputs.
+func newSyncer(opts *options, provider github.Provider) *github.Syncer {
+	syncerOpts := []github.SyncerOption{
+		github.WithRetries(opts.retries),
+		github.WithConcurrency(opts.concurrency),
+		github.WithFields(opts.fields),
+		github.WithReservedPrefixes(opts.reservedPrefixes()),
+		github.WithOperations(opts.operationKinds),
+		github.WithLabelCache(),
+		github.WithCallTimeout(opts.callTimeout, opts.slowCall),
+		github.WithChunkSize(opts.chunkSize),
+	}
+	if len(opts.lockLabel) > 0 {
+		syncerOpts = append(syncerOpts, github.WithLock(opts.lockLabel, opts.lockTimeout))
+	}
+	if opts.rollbackOnFailure {
+		syncerOpts = append(syncerOpts, github.WithRollback())
+	}
+	if opts.onConflict == "update" {
+		syncerOpts = append(syncerOpts, github.WithUpdateOnConflict())
+	}
+	if opts.verbose {
+		syncerOpts = append(syncerOpts, github.WithVerbose())
+	}
+	if opts.strictDescriptions {
+		syncerOpts = append(syncerOpts, github.WithStrictDescriptions())
+	}
+	return github.NewSyncer(provider, syncerOpts...)
+}
+
+// splitTokens returns the newline-separated tokens of token, for the github
+// provider to rotate between.
+func splitTokens(token string) []string {
+	var tokens []string
+	for _, line := range strings.Split(token, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			tokens = append(tokens, line)
+		}
+	}
+	return tokens
+}
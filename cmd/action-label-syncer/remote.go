@@ -0,0 +1,39 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// remoteRegexp matches the owner/repo of remote URLs such as
+// https://github.com/owner/repo.git and git@github.com:owner/repo.git.
+var remoteRegexp = regexp.MustCompile(`[:/]([^/:]+/[^/]+?)(\.git)?/?$`)
+
+// gitRemoteRepository returns the owner/repo of the origin remote of the git
+// repository in the working directory, or "" if there is none.
+func gitRemoteRepository() string {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return ""
+	}
+	m := remoteRegexp.FindStringSubmatch(strings.TrimSpace(string(out)))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
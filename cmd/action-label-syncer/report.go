@@ -0,0 +1,87 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// runReport writes how much every label of the targets is used, to tell which
+// labels are safe to prune. With stale-months, only the managed labels not
+// used by anything updated in that many months are written.
+func runReport(ctx context.Context, opts *options) error {
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+
+	var managed map[string]bool
+	if opts.staleMonths > 0 {
+		m, err := loadManifest(opts)
+		if err != nil {
+			return fmt.Errorf("unable to load manifest: %w", err)
+		}
+		managed = make(map[string]bool, len(m.Labels))
+		for _, l := range m.Labels {
+			managed[l.Name] = true
+		}
+	}
+
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+
+	var usages []github.LabelUsage
+	for _, t := range targets {
+		us, e := client.LabelUsages(ctx, t.owner, t.repo)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to report label usage on %s/%s: %w", t.owner, t.repo, e))
+			continue
+		}
+		usages = append(usages, us...)
+	}
+	if err != nil {
+		return err
+	}
+	if managed != nil {
+		usages = github.StaleLabels(usages, managed, time.Now().AddDate(0, -opts.staleMonths, 0))
+		fmt.Printf("report: %d managed label(s) unused for %d month(s)\n", len(usages), opts.staleMonths)
+	}
+
+	var w io.Writer = os.Stdout
+	if len(opts.reportFile) > 0 {
+		f, err := os.Create(opts.reportFile)
+		if err != nil {
+			return fmt.Errorf("unable to write report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := github.WriteReport(w, opts.reportFormat, usages); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+	if len(opts.reportFile) > 0 {
+		fmt.Printf("report: written to %s\n", opts.reportFile)
+	}
+	return nil
+}
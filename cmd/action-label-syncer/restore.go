@@ -0,0 +1,109 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// runRestore brings the labels of every target back to a backup written by
+// backup-dir or to any other label list in the manifest format.
+func runRestore(ctx context.Context, opts *options) error {
+	if len(opts.backup) == 0 {
+		return errors.New("restore requires a backup file")
+	}
+	// JSON backups are YAML as well, so both formats load the same way.
+	labels, err := github.FromManifestToLabels(opts.backup)
+	if err != nil {
+		return fmt.Errorf("unable to load backup: %w", err)
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+	syncer := newSyncer(opts, provider)
+
+	var in *bufio.Reader
+	if !opts.yes && isInteractive() {
+		in = bufio.NewReader(os.Stdin)
+	}
+
+	for _, t := range targets {
+		t, e := resolveTarget(ctx, opts, provider, nil, t)
+		if e == errSkipped {
+			continue
+		}
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		e = restoreTarget(ctx, opts, syncer, in, t, labels)
+		if e == errAborted {
+			return multierr.Append(err, e)
+		}
+		err = multierr.Append(err, e)
+	}
+	return err
+}
+
+func restoreTarget(ctx context.Context, opts *options, syncer *github.Syncer, in *bufio.Reader, t target, labels []github.Label) (err error) {
+	if !opts.dryRun {
+		unlock, err := syncer.Lock(ctx, t.owner, t.repo)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = multierr.Append(err, unlock())
+		}()
+	}
+
+	plan, err := syncer.PlanRestore(ctx, t.owner, t.repo, labels, opts.prune)
+	if err != nil {
+		return fmt.Errorf("unable to plan restore: %w", err)
+	}
+	keepDefaultLabels(opts, plan)
+	if err := checkDeletions(opts, plan); err != nil {
+		return err
+	}
+	if opts.dryRun {
+		fmt.Print(plan)
+		return nil
+	}
+	if in != nil {
+		if err := confirmDeletions(plan, in); err != nil {
+			return err
+		}
+	}
+	if err := backup(opts, plan); err != nil {
+		return err
+	}
+	if err := syncer.ApplyPlan(ctx, plan); err != nil {
+		return fmt.Errorf("unable to restore labels: %w", err)
+	}
+	return nil
+}
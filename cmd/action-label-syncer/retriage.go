@@ -0,0 +1,89 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// retriage collects the issues losing a label so that they can be
+// re-categorized, if the retriage-file input is set.
+type retriage struct {
+	opts   *options
+	issues []github.AffectedIssue
+}
+
+// record collects the issues affected by plan. It has to be called before plan
+// is applied.
+func (r *retriage) record(ctx context.Context, plan *github.Plan) error {
+	if len(r.opts.retriageFile) == 0 || !plan.Destructive() {
+		return nil
+	}
+	client, err := newGitHubClient(r.opts)
+	if err != nil {
+		return err
+	}
+	issues, err := client.AffectedIssues(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("unable to list affected issues: %w", err)
+	}
+	r.issues = append(r.issues, issues...)
+	return nil
+}
+
+// printImpact prints how many open issues and pull requests lose each label
+// plan deletes or renames, so that reviewers of a dry-run see its blast radius.
+// It's only supported with the github provider.
+func printImpact(ctx context.Context, opts *options, plan *github.Plan) {
+	if opts.provider != "github" || len(opts.snapshot) > 0 || !plan.Destructive() {
+		return
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		github.Warnf("%v", err)
+		return
+	}
+	issues, err := client.AffectedIssues(ctx, plan)
+	if err != nil {
+		github.Warnf("unable to list affected issues on: %s/%s: %v", plan.Owner, plan.Repo, err)
+		return
+	}
+	counts := make(map[string]int)
+	for _, i := range issues {
+		counts[i.Label]++
+	}
+	for _, op := range plan.Operations {
+		switch op.Kind {
+		case github.OperationDelete:
+			fmt.Printf("label: %s removed from %d open issue(s) on: %s/%s\n", op.Label.Name, counts[op.Label.Name], plan.Owner, plan.Repo)
+		case github.OperationRename:
+			fmt.Printf("label: %s renamed on %d open issue(s) on: %s/%s\n", op.From, counts[op.From], plan.Owner, plan.Repo)
+		}
+	}
+}
+
+func (r *retriage) write() error {
+	if len(r.opts.retriageFile) == 0 {
+		return nil
+	}
+	if err := github.WriteAffectedIssues(r.opts.retriageFile, r.issues); err != nil {
+		return fmt.Errorf("unable to write retriage file: %w", err)
+	}
+	fmt.Printf("retriage: %d issue(s) written to %s\n", len(r.issues), r.opts.retriageFile)
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// runReverseSync treats the labels of the target repository as the source of
+// truth: when they have drifted from the manifest, it opens a pull request
+// against the config repository updating the manifest to match them.
+func runReverseSync(ctx context.Context, opts *options) error {
+	// The manifest as written is what's proposed, and the manifest as
+	// synced, with overlays, transforms, sets and the locale of the
+	// repository, what the repository is compared with.
+	raw, err := github.ParseManifest(opts.manifest)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	m, origins, err := layerManifest(opts)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	// Labels of the manifest keep their place when layered, unless they're
+	// duplicated.
+	for i := range raw.Labels {
+		if i >= len(origins) || firstSource(origins[i]) != opts.manifest {
+			return errors.New("reverse-sync requires a manifest without duplicate labels")
+		}
+	}
+
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	if len(targets) != 1 {
+		return errors.New("reverse-sync requires exactly one repository")
+	}
+	t := targets[0]
+
+	configTargets, err := parseTargets(opts.configRepository)
+	if err != nil {
+		return err
+	}
+	if len(configTargets) != 1 {
+		return errors.New("reverse-sync requires exactly one config repository")
+	}
+	config := configTargets[0]
+
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.ListLabels(ctx, t.owner, t.repo)
+	if err != nil {
+		return fmt.Errorf("unable to list labels: %w", err)
+	}
+	// Templates are compared as rendered for the repository, and kept in
+	// the manifest as long as the rendered description matches.
+	synced := github.Localize(m.Labels, t.locale)
+	for _, l := range synced {
+		if !strings.Contains(l.Description, "{{") {
+			continue
+		}
+		repo, err := client.GetRepository(ctx, t.owner, t.repo)
+		if err != nil {
+			return fmt.Errorf("unable to get repository: %w", err)
+		}
+		if synced, err = github.RenderLabels(synced, repo); err != nil {
+			return err
+		}
+		break
+	}
+	plan := github.NewPlan(t.owner, t.repo, current, github.SelectSets(synced, t.labelSets()), true)
+	if len(plan.Operations) == 0 {
+		fmt.Printf("labels on: %s/%s match manifest: %s\n", t.owner, t.repo, opts.manifest)
+		return nil
+	}
+
+	raw.Labels = proposeLabels(opts, t, raw, synced, origins, current)
+	content, err := github.FormatManifest(raw, opts.sort)
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("Update label manifest from %s/%s", t.owner, t.repo)
+	url, err := client.ProposeManifest(ctx, config.owner, config.repo, opts.manifest, opts.reverseSyncBranch, title, content)
+	if err != nil {
+		return fmt.Errorf("unable to open pull request: %w", err)
+	}
+	if len(url) == 0 {
+		fmt.Printf("manifest: %s on: %s/%s is already up to date\n", opts.manifest, config.owner, config.repo)
+		return nil
+	}
+	fmt.Printf("manifest: %s update proposed: %s\n", opts.manifest, url)
+	return nil
+}
+
+// proposeLabels returns the labels of raw, the manifest as written, updated
+// to match current, the labels of t. synced are the labels of the layered
+// manifest as synced to t, the first of which are the labels of raw. Only
+// colors and descriptions differing from synced, with templates rendered, are
+// updated, so the fields only in the manifest, templates and the labels of
+// overlays or of other sets are kept. Labels deleted from t are dropped and labels created on it added.
+func proposeLabels(opts *options, t target, raw *github.Manifest, synced []github.Label, origins []github.Origin, current []github.Label) []github.Label {
+	byName := make(map[string]github.Label, len(current))
+	for _, l := range current {
+		byName[strings.ToLower(l.Name)] = l
+	}
+	managed := make(map[string]bool)
+	for _, l := range synced {
+		managed[strings.ToLower(l.Name)] = true
+		if from := l.RenamedFrom(); len(from) > 0 {
+			managed[strings.ToLower(from)] = true
+		}
+	}
+
+	var labels []github.Label
+	for i, p := range raw.Labels {
+		s := synced[i]
+		// Overlays and other sets are synced from elsewhere.
+		if origins[i].Source != opts.manifest || len(github.SelectSets([]github.Label{s}, t.labelSets())) == 0 {
+			labels = append(labels, p)
+			continue
+		}
+		cur, ok := byName[strings.ToLower(s.Name)]
+		if !ok {
+			// A rename not applied yet keeps the label as it is.
+			if _, renaming := byName[strings.ToLower(s.RenamedFrom())]; renaming && len(s.RenamedFrom()) > 0 {
+				labels = append(labels, p)
+			}
+			continue
+		}
+		cur = github.UnrenderEmoji(cur, p.Emoji, raw.Emoji)
+		if github.NormalizeColor(cur.Color) != github.NormalizeColor(s.Color) {
+			p.Color = github.NormalizeColor(cur.Color)
+		}
+		if github.NormalizeDescription(cur.Description) != github.NormalizeDescription(github.UnrenderEmoji(s, p.Emoji, raw.Emoji).Description) {
+			if _, ok := p.Descriptions[t.locale]; ok && len(t.locale) > 0 {
+				descriptions := make(map[string]string, len(p.Descriptions))
+				for locale, d := range p.Descriptions {
+					descriptions[locale] = d
+				}
+				descriptions[t.locale] = cur.Description
+				p.Descriptions = descriptions
+			} else {
+				p.Description = cur.Description
+			}
+		}
+		// Names are only taken as they are without transforms in between.
+		if len(opts.transforms) == 0 && len(p.NewName) == 0 {
+			p.Name = cur.Name
+		}
+		labels = append(labels, p)
+	}
+	for _, l := range current {
+		if !managed[strings.ToLower(l.Name)] {
+			labels = append(labels, github.Label{Name: l.Name, Description: l.Description, Color: l.Color})
+		}
+	}
+	return labels
+}
+
+// firstSource returns the manifest o first defined the label in.
+func firstSource(o github.Origin) string {
+	if len(o.Overridden) > 0 {
+		return o.Overridden[0]
+	}
+	return o.Source
+}
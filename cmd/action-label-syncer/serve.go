@@ -0,0 +1,225 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// webhookEvent holds the fields of GitHub webhook payloads the server uses.
+type webhookEvent struct {
+	Action string `json:"action"`
+	Label  struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Changes struct {
+		Name struct {
+			From string `json:"from"`
+		} `json:"name"`
+	} `json:"changes"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+type server struct {
+	// opts are the options syncs run with: like a scheduled run, without
+	// confirmation or skipping stamped repositories.
+	opts   *options
+	client *github.Client
+	// targets maps owner/repo to the configured targets.
+	targets map[string]target
+	owners  map[string]bool
+
+	// mu serializes syncs so that events for the same repository don't
+	// interleave.
+	mu sync.Mutex
+}
+
+// runServe receives GitHub webhooks and re-applies the manifest as soon as a
+// managed label is edited or deleted, instead of waiting for the next run, and
+// applies it to newly created repositories.
+func runServe(ctx context.Context, opts *options) error {
+	// Unsigned deliveries would let anyone reaching the server trigger a
+	// pruning sync.
+	if len(opts.webhookSecret) == 0 {
+		return errors.New("serve requires webhook-secret")
+	}
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	// Webhooks are received from GitHub only.
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return err
+	}
+	syncOpts := *opts
+	syncOpts.yes = true
+	// Labels edited by hand leave the stamp as it was.
+	syncOpts.skipStamped = false
+	s := &server{
+		opts:   &syncOpts,
+		client: client,
+	}
+	// Without explicit repositories every repository sending events is
+	// synced, e.g. for an organization webhook.
+	if len(targets) > 0 {
+		s.targets = make(map[string]target)
+		s.owners = make(map[string]bool)
+		for _, t := range targets {
+			s.targets[t.owner+"/"+t.repo] = t
+			s.owners[t.owner] = true
+		}
+	}
+
+	fmt.Printf("listening on: %s\n", opts.addr)
+	return http.ListenAndServe(opts.addr, s)
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !validSignature(s.opts.webhookSecret, r.Header.Get("X-Hub-Signature-256"), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var e webhookEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t := target{owner: e.Repository.Owner.Login, repo: e.Repository.Name}
+
+	switch r.Header.Get("X-GitHub-Event") {
+	case "repository":
+		// New repositories can't be listed in advance, so any repository
+		// of a configured owner is accepted.
+		if e.Action != "created" || (s.owners != nil && !s.owners[t.owner]) {
+			break
+		}
+		go s.bootstrap(t)
+	case "label":
+		if s.targets != nil {
+			configured, ok := s.targets[t.owner+"/"+t.repo]
+			if !ok {
+				break
+			}
+			t = configured
+		}
+		if e.Action != "edited" && e.Action != "deleted" {
+			break
+		}
+		name := e.Label.Name
+		if len(e.Changes.Name.From) > 0 {
+			name = e.Changes.Name.From
+		}
+		go s.enforce(t, name)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// enforce syncs the labels of t if name is managed by the manifest.
+func (s *server) enforce(t target, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := loadManifest(s.opts)
+	if err != nil {
+		log.Printf("unable to load manifest: %v", err)
+		return
+	}
+	labels := m.Labels
+	managed := false
+	for _, l := range t.labels(labels) {
+		if l.Name == name {
+			managed = true
+			break
+		}
+	}
+	if !managed {
+		return
+	}
+
+	fmt.Printf("label: %s changed on: %s/%s, syncing labels\n", name, t.owner, t.repo)
+	s.sync(t, labels)
+}
+
+// bootstrap applies the manifest to the newly created repository t.
+func (s *server) bootstrap(t target) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := loadManifest(s.opts)
+	if err != nil {
+		log.Printf("unable to load manifest: %v", err)
+		return
+	}
+
+	fmt.Printf("repository: %s/%s created, syncing labels\n", t.owner, t.repo)
+	s.sync(t, m.Labels)
+}
+
+// sync syncs labels to t as a scheduled run does, with the same guards,
+// policies and state.
+func (s *server) sync(t target, labels []github.Label) {
+	since := github.Warnings()
+	err := syncTargets(context.Background(), s.opts, s.client, []target{t}, labels, nil)
+	if err == nil {
+		err = checkWarnings(s.opts, since)
+	}
+	if err != nil {
+		log.Printf("unable to sync labels on %s/%s: %v", t.owner, t.repo, err)
+	}
+}
+
+// validSignature reports whether signature is the HMAC of body keyed with
+// secret, as sent by GitHub. Nothing is valid without a secret.
+func validSignature(secret, signature string, body []byte) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	sum, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
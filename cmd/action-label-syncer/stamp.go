@@ -0,0 +1,100 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// stamp records on t that it has been synced with labels, as a topic or a
+// custom property as the stamp input tells, so that whether a repository is
+// up to date can be told without listing its labels. Only complete syncs
+// stamp: a sync without prune, limited by the operations or enforce inputs, or
+// keeping or skipping some of its planned operations may leave the repository
+// differing from the manifest, and later runs with skip-stamped would skip it
+// for good.
+func stamp(ctx context.Context, opts *options, client *github.Client, t target, labels []github.Label, complete bool) error {
+	if len(opts.stamp) == 0 {
+		return nil
+	}
+	if !opts.prune || opts.operationKinds != nil || opts.fields != github.AllFields || !complete {
+		fmt.Printf("%s: %s not stamped on: %s/%s, the sync left labels differing from the manifest\n", opts.stamp, opts.stampName, t.owner, t.repo)
+		return nil
+	}
+	hash, err := stampHash(opts, t, labels)
+	if err != nil {
+		return err
+	}
+	switch opts.stamp {
+	case "topic":
+		err = client.StampTopic(ctx, t.owner, t.repo, opts.stampName, hash)
+	case "property":
+		err = client.StampProperty(ctx, t.owner, t.repo, opts.stampName, hash)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to stamp %s/%s: %w", t.owner, t.repo, err)
+	}
+	fmt.Printf("%s: %s stamped with: %s on: %s/%s\n", opts.stamp, opts.stampName, hash, t.owner, t.repo)
+	return nil
+}
+
+// stampHash returns the hash t is stamped with once synced with labels.
+func stampHash(opts *options, t target, labels []github.Label) (string, error) {
+	return github.SyncHash(t.labels(labels), opts.reservedPrefixes())
+}
+
+// stamps tells whether targets are stamped with the current labels, listing
+// the custom properties of each organization once.
+type stamps struct {
+	opts       *options
+	client     *github.Client
+	properties map[string]map[string]string
+}
+
+// upToDate reports whether t is stamped with the hash of labels, in which case
+// syncing it can be skipped. It is always false without skip-stamped.
+func (s *stamps) upToDate(ctx context.Context, t target, labels []github.Label) (bool, error) {
+	if !s.opts.skipStamped || s.client == nil {
+		return false, nil
+	}
+	hash, err := stampHash(s.opts, t, labels)
+	if err != nil {
+		return false, err
+	}
+	var stamped string
+	switch s.opts.stamp {
+	case "topic":
+		stamped, err = s.client.StampedTopic(ctx, t.owner, t.repo, s.opts.stampName)
+	case "property":
+		values, ok := s.properties[t.owner]
+		if !ok {
+			values, err = s.client.StampedProperties(ctx, t.owner, s.opts.stampName)
+			if s.properties == nil {
+				s.properties = make(map[string]map[string]string)
+			}
+			s.properties[t.owner] = values
+		}
+		stamped = values[t.repo]
+	}
+	if err != nil {
+		return false, err
+	}
+	return stamped == hash, nil
+}
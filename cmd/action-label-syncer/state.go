@@ -0,0 +1,103 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// readState returns the state of the state input, or nil without one.
+func readState(opts *options) (*github.State, error) {
+	if len(opts.state) == 0 {
+		return nil, nil
+	}
+	st, err := github.ReadState(opts.state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state: %w", err)
+	}
+	return st, nil
+}
+
+func writeState(opts *options, st *github.State) error {
+	if st == nil {
+		return nil
+	}
+	if err := github.WriteState(opts.state, st); err != nil {
+		return fmt.Errorf("unable to write state: %w", err)
+	}
+	return nil
+}
+
+// keepUnmanagedLabels removes from plan the deletions of labels the state
+// doesn't manage.
+func keepUnmanagedLabels(opts *options, st *github.State, plan *github.Plan) {
+	if st == nil {
+		return
+	}
+	printKept(opts, plan, plan.Keep(st.Unmanaged(plan)), "not managed")
+}
+
+// runAdopt records the labels of the targets that are in the manifest as
+// managed without modifying them, so that syncing with a state can be adopted
+// by existing repositories safely.
+func runAdopt(ctx context.Context, opts *options) error {
+	if len(opts.state) == 0 {
+		return errors.New("adopt requires a state file")
+	}
+	m, err := loadManifest(opts)
+	if err != nil {
+		return fmt.Errorf("unable to load manifest: %w", err)
+	}
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+	st, err := readState(opts)
+	if err != nil {
+		return err
+	}
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range targets {
+		t, e := resolveTarget(ctx, opts, provider, st, t)
+		if e == errSkipped {
+			continue
+		}
+		if e != nil {
+			err = multierr.Append(err, e)
+			continue
+		}
+		current, e := provider.ListLabels(ctx, t.owner, t.repo)
+		if e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to list labels: %w", e))
+			continue
+		}
+		for _, name := range st.Adopt(t.owner, t.repo, current, m.Labels) {
+			fmt.Printf("label: %s adopted on: %s/%s\n", name, t.owner, t.repo)
+		}
+	}
+	if opts.dryRun {
+		return err
+	}
+	return multierr.Append(err, writeState(opts, st))
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/notify"
+)
+
+// runSummarize combines the result files written by the legs of a matrix with
+// result-file into one job summary and exit status. The files are given with
+// the results input or as arguments, or else downloaded from the artifacts of
+// the workflow run.
+func runSummarize(ctx context.Context, opts *options) error {
+	files, err := resultFiles(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("summarize found no result files")
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []notify.Result
+	for _, name := range names {
+		rs, err := notify.ParseResults(files[name])
+		if err != nil {
+			return fmt.Errorf("unable to read results: %s: %w", name, err)
+		}
+		results = append(results, rs...)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Repository < results[j].Repository
+	})
+
+	summary := notify.Summary(results)
+	fmt.Print(summary)
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); len(path) > 0 {
+		if err := appendFile(path, summary); err != nil {
+			return fmt.Errorf("unable to write job summary: %w", err)
+		}
+	}
+	if failed := notify.Failed(results); failed > 0 {
+		return fmt.Errorf("label sync failed on %d of %d repositories", failed, len(results))
+	}
+	return nil
+}
+
+// resultFiles returns the contents of the result files by name.
+func resultFiles(ctx context.Context, opts *options) (map[string][]byte, error) {
+	var paths []string
+	for _, pattern := range strings.Split(opts.results, "\n") {
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid results pattern: %s: %w", pattern, err)
+		}
+		paths = append(paths, matches...)
+	}
+	paths = append(paths, opts.args...)
+	if len(paths) > 0 {
+		files := make(map[string][]byte, len(paths))
+		for _, path := range paths {
+			buf, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read results: %w", err)
+			}
+			files[path] = buf
+		}
+		return files, nil
+	}
+
+	runID := opts.runID
+	if runID == 0 {
+		id, err := strconv.ParseInt(os.Getenv("GITHUB_RUN_ID"), 10, 64)
+		if err != nil {
+			return nil, errors.New("summarize requires result files or a workflow run")
+		}
+		runID = id
+	}
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) != 1 {
+		return nil, errors.New("summarize requires exactly one repository running the workflow")
+	}
+	client, err := newGitHubClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	files, err := client.RunArtifactFiles(ctx, targets[0].owner, targets[0].repo, runID, opts.artifactPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download results: %w", err)
+	}
+	for name := range files {
+		if filepath.Ext(name) != ".json" {
+			delete(files, name)
+		}
+	}
+	fmt.Printf("summarize: %d result file(s) downloaded from run: %d\n", len(files), runID)
+	return files, nil
+}
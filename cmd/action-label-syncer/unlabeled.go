@@ -0,0 +1,56 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// labelUnlabeledIssues adds the default-label input to the open issues of t
+// without any label, after syncing it. With dry-run, it only prints them.
+func labelUnlabeledIssues(ctx context.Context, opts *options, client *github.Client, t target) error {
+	if len(opts.defaultLabel) == 0 {
+		return nil
+	}
+	numbers, err := client.UnlabeledIssues(ctx, t.owner, t.repo)
+	if err != nil {
+		return fmt.Errorf("unable to list unlabeled issues of %s/%s: %w", t.owner, t.repo, err)
+	}
+	for _, n := range numbers {
+		if opts.dryRun {
+			fmt.Printf("label: %s would be added to unlabeled issue: %s/%s#%d\n", opts.defaultLabel, t.owner, t.repo, n)
+			continue
+		}
+		if e := client.AddLabels(ctx, t.owner, t.repo, n, []string{opts.defaultLabel}); e != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to label %s/%s#%d: %w", t.owner, t.repo, n, e))
+			continue
+		}
+		fmt.Printf("label: %s added to unlabeled issue: %s/%s#%d\n", opts.defaultLabel, t.owner, t.repo, n)
+	}
+	return err
+}
+
+func hasLabel(labels []github.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
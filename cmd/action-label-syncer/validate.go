@@ -0,0 +1,79 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+// validateManifest validates the manifest and its overlays as
+// github.ValidateManifest does, with the descriptions too long truncated
+// unless description-overflow is fail, and returns the manifest as
+// loadManifest does. Once every file is valid, the labels are validated as
+// layered and transformed too, e.g. for transforms making names collide.
+func validateManifest(opts *options) (*github.Manifest, error) {
+	err := validateManifestFile(opts, opts.manifest)
+	for _, overlay := range opts.overlays() {
+		for _, e := range multierr.Errors(validateManifestFile(opts, overlay)) {
+			err = multierr.Append(err, fmt.Errorf("%s: %w", overlay, e))
+		}
+	}
+	m, _, e := layerManifest(opts)
+	if e != nil {
+		// Files failing to parse fail to layer as well.
+		if err == nil {
+			err = e
+		}
+		return nil, err
+	}
+	if err == nil {
+		err = github.ValidateLabels(m.Labels)
+	}
+	return m, err
+}
+
+func validateManifestFile(opts *options, path string) error {
+	if opts.descriptionOverflow == "fail" {
+		_, err := github.ValidateManifest(path)
+		return err
+	}
+	// The truncations are printed once the manifest is layered.
+	_, _, err := github.ValidateTruncatedManifest(path)
+	return err
+}
+
+// runValidate checks the manifest without calling the GitHub API, so it needs
+// neither a token nor a repository.
+func runValidate(opts *options) error {
+	m, err := validateManifest(opts)
+	if m != nil {
+		err = multierr.Combine(err, validateConfig(m), github.ValidateReserved(m.Labels, opts.reservedPrefixes()), opts.naming.Validate(m.Labels))
+	}
+	if err != nil {
+		errs := multierr.Errors(err)
+		for _, e := range errs {
+			fmt.Printf("%s: %v\n", opts.manifest, e)
+		}
+		return fmt.Errorf("invalid manifest: %s: %d problem(s) found", opts.manifest, len(errs))
+	}
+	if err := checkContrast(opts, m.Labels); err != nil {
+		return err
+	}
+	fmt.Printf("manifest: %s is valid (%d labels, %d milestones)\n", opts.manifest, len(m.Labels), len(m.Milestones))
+	return nil
+}
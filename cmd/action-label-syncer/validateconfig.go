@@ -0,0 +1,111 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v2"
+)
+
+// runValidateConfig checks the config file and each of its profiles without
+// calling the GitHub API: the inputs are known and parse, the repositories are
+// well-formed, the policies are recognized and the manifests exist. Only the
+// config is checked, regardless of the command line and the environment.
+func runValidateConfig(opts *options) error {
+	if len(opts.config) == 0 {
+		return errors.New("validate-config requires config")
+	}
+	buf, err := ioutil.ReadFile(opts.config)
+	if err != nil {
+		return fmt.Errorf("unable to read config: %w", err)
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(buf, &config); err != nil {
+		return fmt.Errorf("unable to parse config: %s: %w", opts.config, err)
+	}
+	profiles, err := parseProfiles(config["profiles"])
+	if err != nil {
+		return fmt.Errorf("unable to parse profiles: %s: %w", opts.config, err)
+	}
+	names := []string{""}
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+
+	// Problems of the top-level inputs are reported once rather than for
+	// every profile inheriting them.
+	var problems int
+	inherited := make(map[string]bool)
+	for _, name := range names {
+		for _, e := range multierr.Errors(validateProfile(opts.config, name)) {
+			if inherited[e.Error()] {
+				continue
+			}
+			problems++
+			if len(name) == 0 {
+				inherited[e.Error()] = true
+				fmt.Printf("%s: %v\n", opts.config, e)
+				continue
+			}
+			fmt.Printf("%s: profile %s: %v\n", opts.config, name, e)
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("invalid config: %s: %d problem(s) found", opts.config, problems)
+	}
+	fmt.Printf("config: %s is valid (%d profiles)\n", opts.config, len(profiles))
+	return nil
+}
+
+// validateProfile checks the inputs of the config at path with profile
+// selected, or none if empty.
+func validateProfile(path, profile string) error {
+	o := &options{}
+	fs := newFlagSet(o)
+	fs.Set("config", path)
+	fs.Set("profile", profile)
+	err := applyConfig(fs, map[string]bool{"config": true, "profile": true})
+	err = multierr.Append(err, o.parsePolicies())
+
+	switch o.provider {
+	case "github", "gitlab", "gitea":
+	default:
+		err = multierr.Append(err, fmt.Errorf("unknown provider: %s", o.provider))
+	}
+	if _, e := parseTargets(o.repository); e != nil {
+		err = multierr.Append(err, e)
+	}
+	if _, e := parseTargets(o.configRepository); e != nil {
+		err = multierr.Append(err, fmt.Errorf("config-repository: %w", e))
+	}
+
+	if len(o.manifest) == 0 {
+		o.manifest = findManifest()
+	}
+	manifests := append([]string{o.manifest}, o.overlays()...)
+	for _, m := range manifests {
+		if _, e := os.Stat(m); e != nil {
+			err = multierr.Append(err, fmt.Errorf("manifest: %w", e))
+		}
+	}
+	return err
+}
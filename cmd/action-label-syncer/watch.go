@@ -0,0 +1,150 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+	"go.uber.org/multierr"
+)
+
+const watchInterval = time.Second
+
+// runWatch prints the plan for every target again each time the manifest
+// changes on disk. The labels of the targets are fetched once at start, so
+// editing the manifest doesn't cost any API calls for them.
+func runWatch(ctx context.Context, opts *options) error {
+	targets, err := parseTargets(opts.repository)
+	if err != nil {
+		return err
+	}
+
+	provider, err := newProvider(opts)
+	if err != nil {
+		return err
+	}
+	st, err := readState(opts)
+	if err != nil {
+		return err
+	}
+
+	f := &frozen{
+		Provider: provider,
+		labels:   make(map[string][]github.Label),
+		repos:    make(map[string]*github.Repository),
+	}
+	for _, t := range targets {
+		name := t.owner + "/" + t.repo
+		labels, err := provider.ListLabels(ctx, t.owner, t.repo)
+		if err != nil {
+			return fmt.Errorf("unable to list labels: %w", err)
+		}
+		f.labels[name] = labels
+
+		f.repos[name] = &github.Repository{Owner: t.owner, Name: t.repo}
+		if g, ok := provider.(github.RepositoryGetter); ok {
+			if f.repos[name], err = g.GetRepository(ctx, t.owner, t.repo); err != nil {
+				return fmt.Errorf("unable to get repository: %w", err)
+			}
+		}
+	}
+
+	var modTime time.Time
+	for {
+		if fi, err := os.Stat(opts.manifest); err == nil && !fi.ModTime().Equal(modTime) {
+			modTime = fi.ModTime()
+			fmt.Printf("--- manifest: %s changed at %s\n", opts.manifest, modTime.Format(time.Kitchen))
+			printWatchPlans(ctx, opts, f, st, targets)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// printWatchPlans prints the plans of targets as plan does.
+func printWatchPlans(ctx context.Context, opts *options, provider github.Provider, st *github.State, targets []target) {
+	m, err := validateManifest(opts)
+	if err != nil {
+		for _, e := range multierr.Errors(err) {
+			fmt.Printf("%s: %v\n", opts.manifest, e)
+		}
+		return
+	}
+	syncer := newSyncer(opts, provider)
+	for _, t := range targets {
+		plan, err := syncer.PlanLabels(ctx, t.owner, t.repo, t.labels(m.Labels), opts.prune)
+		if err != nil {
+			fmt.Printf("%s: %v\n", opts.manifest, err)
+			return
+		}
+		keepUnmanagedLabels(opts, st, plan)
+		keepDefaultLabels(opts, plan)
+		if err := keepNewLabels(ctx, opts, plan); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := keepUsedLabels(ctx, opts, plan); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Print(plan)
+		if err := plan.Validate(); err != nil {
+			fmt.Printf("invalid plan: %v\n", err)
+		}
+		if err := checkDeletions(opts, plan); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// frozen is a Provider serving the labels and metadata of repositories as
+// fetched at start, so that planning again doesn't call the API. Changes go to
+// the provider, but plans are never applied while watching.
+type frozen struct {
+	github.Provider
+	labels map[string][]github.Label
+	repos  map[string]*github.Repository
+}
+
+var (
+	_ github.RepositoryGetter = (*frozen)(nil)
+	_ github.FieldSupporter   = (*frozen)(nil)
+)
+
+func (f *frozen) ListLabels(ctx context.Context, owner, repo string) ([]github.Label, error) {
+	return append([]github.Label(nil), f.labels[owner+"/"+repo]...), nil
+}
+
+func (f *frozen) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	return f.repos[owner+"/"+repo], nil
+}
+
+func (f *frozen) SupportsField(field string) bool {
+	s, ok := f.Provider.(github.FieldSupporter)
+	return ok && s.SupportsField(field)
+}
+
+func (f *frozen) DescriptionsUnsupported() bool {
+	d, ok := f.Provider.(github.DescriptionSupporter)
+	return ok && d.DescriptionsUnsupported()
+}
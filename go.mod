@@ -8,5 +8,6 @@ require (
 	go.uber.org/multierr v1.7.0
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
+	golang.org/x/text v0.3.7
 	gopkg.in/yaml.v2 v2.4.0
 )
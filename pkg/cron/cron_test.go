@@ -0,0 +1,82 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "0 3 * * 1-5"},
+		{expr: "*/15 0,12 1 1-12/2 0"},
+		{expr: "5/10 * * * *"},
+		{expr: "* * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 7", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2020-01-01 is a Wednesday.
+	from := time.Date(2020, 1, 1, 10, 30, 15, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2020, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2020, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 3 * * 1-5", want: time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 0", want: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{expr: "*/20 * * * *", want: time.Date(2020, 1, 1, 10, 40, 0, 0, time.UTC)},
+		{expr: "0 0 1 3 *", want: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either matches.
+		{expr: "0 0 15 * 5", want: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 31 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitea implements the label provider for Gitea and Forgejo
+// repository labels.
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// pageSize is the default maximum page size of Gitea.
+const pageSize = 50
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// label is a Gitea repository label.
+type label struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Exclusive   *bool  `json:"exclusive,omitempty"`
+}
+
+var (
+	_ github.Provider         = (*Client)(nil)
+	_ github.RepositoryGetter = (*Client)(nil)
+	_ github.FieldSupporter   = (*Client)(nil)
+)
+
+// NewClient returns a client of the Gitea instance at baseURL, e.g.
+// https://gitea.example.com.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		token:      token,
+	}
+}
+
+// ListLabels returns the labels of owner/repo.
+func (c *Client) ListLabels(ctx context.Context, owner, repo string) ([]github.Label, error) {
+	ls, err := c.listLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]github.Label, 0, len(ls))
+	for _, l := range ls {
+		labels = append(labels, github.Label{
+			Name:        l.Name,
+			Description: l.Description,
+			Color:       github.NormalizeColor(l.Color),
+			Exclusive:   l.Exclusive,
+		})
+	}
+	return labels, nil
+}
+
+// CreateLabel creates label on owner/repo.
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, l github.Label) error {
+	body := &label{
+		Name:        l.Name,
+		Description: l.Description,
+		Color:       "#" + l.Color,
+		Exclusive:   l.Exclusive,
+	}
+	return c.do(ctx, http.MethodPost, c.labelsURL(owner, repo), body, nil)
+}
+
+// UpdateLabel updates the label name on owner/repo to l, renaming it if the
+// names differ.
+func (c *Client) UpdateLabel(ctx context.Context, owner, repo, name string, l github.Label) error {
+	id, err := c.labelID(ctx, owner, repo, name)
+	if err != nil {
+		return err
+	}
+	body := &label{
+		Name:        l.Name,
+		Description: l.Description,
+		Color:       "#" + l.Color,
+		Exclusive:   l.Exclusive,
+	}
+	return c.do(ctx, http.MethodPatch, c.labelURL(owner, repo, id), body, nil)
+}
+
+// DeleteLabel deletes the label name from owner/repo.
+func (c *Client) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	id, err := c.labelID(ctx, owner, repo, name)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, c.labelURL(owner, repo, id), nil, nil)
+}
+
+// SupportsField reports whether field is supported, which only exclusive is.
+func (c *Client) SupportsField(field string) bool {
+	return field == github.FieldExclusive
+}
+
+func (c *Client) listLabels(ctx context.Context, owner, repo string) ([]label, error) {
+	var labels []label
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("limit", strconv.Itoa(pageSize))
+		q.Set("page", strconv.Itoa(page))
+
+		var ls []label
+		if err := c.do(ctx, http.MethodGet, c.labelsURL(owner, repo)+"?"+q.Encode(), nil, &ls); err != nil {
+			return nil, err
+		}
+		labels = append(labels, ls...)
+		if len(ls) < pageSize {
+			return labels, nil
+		}
+	}
+}
+
+// labelID returns the ID of the label name, as Gitea addresses labels by ID
+// rather than by name.
+func (c *Client) labelID(ctx context.Context, owner, repo, name string) (int64, error) {
+	ls, err := c.listLabels(ctx, owner, repo)
+	if err != nil {
+		return 0, err
+	}
+	for _, l := range ls {
+		if l.Name == name {
+			return l.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s on: %s/%s", github.ErrNotFound, name, owner, repo)
+}
+
+// GetRepository returns the metadata of owner/repo.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	var r struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.repoURL(owner, repo), nil, &r); err != nil {
+		return nil, err
+	}
+	return &github.Repository{
+		Owner:         owner,
+		Name:          repo,
+		DefaultBranch: r.DefaultBranch,
+	}, nil
+}
+
+func (c *Client) repoURL(owner, repo string) string {
+	return fmt.Sprintf("%s/repos/%s/%s", c.baseURL, url.PathEscape(owner), url.PathEscape(repo))
+}
+
+func (c *Client) labelsURL(owner, repo string) string {
+	return c.repoURL(owner, repo) + "/labels"
+}
+
+func (c *Client) labelURL(owner, repo string, id int64) string {
+	return fmt.Sprintf("%s/%d", c.labelsURL(owner, repo), id)
+}
+
+// do sends a request with body encoded as JSON and decodes the response into v
+// if not nil.
+func (c *Client) do(ctx context.Context, method, u string, body, v interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if len(c.token) > 0 {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return &github.HTTPError{
+			Method:     method,
+			URL:        u,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       strings.TrimSpace(string(b)),
+			Header:     resp.Header,
+		}
+	}
+	if v != nil {
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+	return nil
+}
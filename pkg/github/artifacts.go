@@ -0,0 +1,123 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+)
+
+// RunArtifactFiles returns the contents of the files in the artifacts of the
+// workflow run runID of owner/repo whose names match pattern, keyed by
+// artifact name and file name, e.g. "results-1/results.json".
+func (c *Client) RunArtifactFiles(ctx context.Context, owner, repo string, runID int64, pattern string) (map[string][]byte, error) {
+	type artifact struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"archive_download_url"`
+		Expired     bool   `json:"expired"`
+	}
+	var artifacts []artifact
+	for page := 1; page != 0; {
+		var list struct {
+			Artifacts []artifact `json:"artifacts"`
+		}
+		u := fmt.Sprintf("repos/%s/%s/actions/runs/%d/artifacts?per_page=100&page=%d", owner, repo, runID, page)
+		resp, err := c.plainRequest(ctx, "GET", u, nil, &list)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, list.Artifacts...)
+		page = resp.NextPage
+	}
+
+	files := make(map[string][]byte)
+	for _, a := range artifacts {
+		if ok, err := path.Match(pattern, a.Name); err != nil || !ok || a.Expired {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		buf, err := c.downloadArtifact(ctx, a.DownloadURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to download artifact %s: %w", a.Name, err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read artifact %s: %w", a.Name, err)
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			b, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			files[a.Name+"/"+f.Name] = b
+		}
+	}
+	return files, nil
+}
+
+// downloadArtifact returns the zip archive of an artifact. The API redirects
+// to a signed URL of the storage, which must be fetched without the token.
+func (c *Client) downloadArtifact(ctx context.Context, u string) ([]byte, error) {
+	if c.httpClient == nil {
+		return nil, errors.New("client has no HTTP client")
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	noRedirect := *c.httpClient
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := noRedirect.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if len(location) == 0 {
+		return nil, fmt.Errorf("%s: no redirect to the archive", resp.Status)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
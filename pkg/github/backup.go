@@ -0,0 +1,72 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Destructive reports whether applying p deletes or renames labels, or
+// overwrites their descriptions or colors.
+func (p *Plan) Destructive() bool {
+	for _, op := range p.Operations {
+		if op.Kind == OperationDelete || op.Kind == OperationUpdate || op.Kind == OperationRename {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteBackup writes the labels of the repository as they were before p to a
+// timestamped file in dir and returns its path. With format "yaml" the backup
+// is a manifest itself, so it can be synced back as is; "json" is also
+// supported.
+func WriteBackup(dir, format string, p *Plan, now time.Time) (string, error) {
+	var (
+		buf []byte
+		err error
+	)
+	switch format {
+	case "yaml":
+		buf, err = FormatLabels(p.Current, "name")
+	case "json":
+		buf, err = json.MarshalIndent(p.Current, "", "  ")
+		buf = append(buf, '\n')
+	default:
+		return "", fmt.Errorf("unknown backup format: %s", format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	ext := format
+	if ext == "yaml" {
+		ext = "yml"
+	}
+	// GitLab subgroups put slashes in the owner.
+	owner := strings.ReplaceAll(p.Owner, "/", "-")
+	name := fmt.Sprintf("%s-%s-%s.%s", owner, p.Repo, now.UTC().Format("20060102T150405Z"), ext)
+	path := filepath.Join(dir, name)
+	return path, ioutil.WriteFile(path, buf, 0644)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		owner  string
+		format string
+		want   string
+	}{
+		{owner: "owner", format: "yaml", want: "owner-repo-20200102T030405Z.yml"},
+		{owner: "owner", format: "json", want: "owner-repo-20200102T030405Z.json"},
+		{owner: "group/subgroup", format: "yaml", want: "group-subgroup-repo-20200102T030405Z.yml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			plan := &Plan{Owner: tt.owner, Repo: "repo", Current: []Label{{Name: "bug", Color: "d73a4a"}}}
+			path, err := WriteBackup(dir, tt.format, plan, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := filepath.Join(dir, tt.want); path != want {
+				t.Errorf("got %s, want %s", path, want)
+			}
+			if _, err := os.Stat(path); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
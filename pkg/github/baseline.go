@@ -0,0 +1,95 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Baseline lists the accepted drift of repositories, so that checking drift
+// can be adopted on repositories that have drifted already and only fail on
+// new drift.
+type Baseline struct {
+	// Repositories maps owner/repo to its accepted operations, each written
+	// as "<kind> <label name>", e.g. "delete wontfix".
+	Repositories map[string][]string `yaml:"repositories"`
+}
+
+// ReadBaseline reads the baseline at path. A missing file is an empty
+// baseline.
+func ReadBaseline(path string) (*Baseline, error) {
+	b := &Baseline{Repositories: make(map[string][]string)}
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(buf, b); err != nil {
+		return nil, err
+	}
+	if b.Repositories == nil {
+		b.Repositories = make(map[string][]string)
+	}
+	return b, nil
+}
+
+// WriteBaseline writes b to path.
+func WriteBaseline(path string, b *Baseline) error {
+	buf, err := yaml.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf, 0644)
+}
+
+// NewDrift returns the operations of p not accepted by b.
+func (b *Baseline) NewDrift(p *Plan) []Operation {
+	accepted := make(map[string]bool)
+	for _, e := range b.Repositories[p.Owner+"/"+p.Repo] {
+		accepted[e] = true
+	}
+	var ops []Operation
+	for _, op := range p.Operations {
+		if !accepted[baselineEntry(op)] {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// Accept sets the accepted drift of the repository of p to its operations.
+func (b *Baseline) Accept(p *Plan) {
+	key := p.Owner + "/" + p.Repo
+	if len(p.Operations) == 0 {
+		delete(b.Repositories, key)
+		return
+	}
+	entries := make([]string, 0, len(p.Operations))
+	for _, op := range p.Operations {
+		entries = append(entries, baselineEntry(op))
+	}
+	sort.Strings(entries)
+	b.Repositories[key] = entries
+}
+
+func baselineEntry(op Operation) string {
+	return string(op.Kind) + " " + op.Label.Name
+}
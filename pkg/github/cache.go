@@ -0,0 +1,111 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import "sync"
+
+// WithLabelCache makes the Syncer list the labels of a repository only once,
+// e.g. when the same repository is synced by several passes of a run. The
+// cached labels follow the changes made by the Syncer, and are listed again
+// after a change fails. It's meant for short-lived Syncers: changes made by
+// others aren't seen.
+func WithLabelCache() SyncerOption {
+	return func(s *Syncer) {
+		s.cache = &labelCache{labels: make(map[string][]Label)}
+	}
+}
+
+// labelCache holds the labels of repositories keyed by owner/repo. A nil
+// labelCache caches nothing.
+type labelCache struct {
+	mu     sync.Mutex
+	labels map[string][]Label
+}
+
+func (c *labelCache) get(owner, repo string) ([]Label, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	labels, ok := c.labels[owner+"/"+repo]
+	if !ok {
+		return nil, false
+	}
+	// Callers may filter the labels in place.
+	return append([]Label(nil), labels...), true
+}
+
+func (c *labelCache) put(owner, repo string, labels []Label) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.labels[owner+"/"+repo] = append([]Label(nil), labels...)
+}
+
+func (c *labelCache) invalidate(owner, repo string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.labels, owner+"/"+repo)
+}
+
+// apply updates the cached labels of owner/repo, if any, with op having been
+// applied.
+func (c *labelCache) apply(owner, repo string, op Operation) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := owner + "/" + repo
+	labels, ok := c.labels[key]
+	if !ok {
+		return
+	}
+
+	name := op.Label.Name
+	switch {
+	case op.Previous != nil:
+		name = op.Previous.Name
+	case op.Kind == OperationRename:
+		name = op.From
+	}
+	label := Label{
+		Name:        op.Label.Name,
+		Description: op.Label.Description,
+		Color:       op.Label.Color,
+		Priority:    op.Label.Priority,
+		Exclusive:   op.Label.Exclusive,
+	}
+	updated := labels[:0]
+	for _, l := range labels {
+		if l.Name != name {
+			updated = append(updated, l)
+			continue
+		}
+		if op.Kind == OperationUpdate || op.Kind == OperationRename {
+			updated = append(updated, label)
+		}
+	}
+	if op.Kind == OperationCreate {
+		updated = append(updated, label)
+	}
+	c.labels[key] = updated
+}
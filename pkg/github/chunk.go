@@ -0,0 +1,51 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithChunkSize makes the Syncer apply plans in chunks of n operations, in
+// order, printing a checkpoint after each chunk, so that how far an
+// interrupted sync got is obvious from its log. Zero applies a plan at once.
+func WithChunkSize(n int) SyncerOption {
+	return func(s *Syncer) {
+		s.chunkSize = n
+	}
+}
+
+// applyChunks executes ops with applyAll a chunk at a time, stopping at the
+// first chunk failing. done is the number of operations of the plan applied
+// before ops and total the number of its operations, for checkpoints.
+func (s *Syncer) applyChunks(ctx context.Context, j *journal, owner, repo string, ops []Operation, done, total int) error {
+	if s.chunkSize <= 0 {
+		return s.applyAll(ctx, j, owner, repo, ops)
+	}
+	for len(ops) > 0 {
+		n := s.chunkSize
+		if n > len(ops) {
+			n = len(ops)
+		}
+		if err := s.applyAll(ctx, j, owner, repo, ops[:n]); err != nil {
+			return err
+		}
+		done += n
+		fmt.Printf("checkpoint: %d/%d operation(s) applied on: %s/%s, last: %s %s\n", done, total, owner, repo, ops[n-1].Kind, ops[n-1].Label.Name)
+		ops = ops[n:]
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	// MinContrastRatio is the WCAG AA minimum contrast ratio of normal text.
+	MinContrastRatio = 4.5
+
+	// lightnessThreshold is the perceived lightness above which GitHub
+	// draws label names in black rather than white.
+	lightnessThreshold = 0.453
+)
+
+// ContrastRatio returns the WCAG contrast ratio between color and the text
+// color GitHub draws label names with on it.
+func ContrastRatio(color string) (float64, error) {
+	n, err := strconv.ParseUint(NormalizeColor(color), 16, 32)
+	if err != nil || len(NormalizeColor(color)) != 6 {
+		return 0, fmt.Errorf("invalid color: %s", color)
+	}
+	r, g, b := float64(n>>16&0xff)/255, float64(n>>8&0xff)/255, float64(n&0xff)/255
+
+	bg := luminance(r, g, b)
+	text := 1.0 // white
+	if r*0.2126+g*0.7152+b*0.0722 > lightnessThreshold {
+		text = 0 // black
+	}
+	lighter, darker := math.Max(bg, text), math.Min(bg, text)
+	return (lighter + 0.05) / (darker + 0.05), nil
+}
+
+// LowContrastLabels returns a problem for every label whose color contrasts
+// with its name less than MinContrastRatio.
+func LowContrastLabels(labels []Label) []error {
+	var problems []error
+	for _, l := range labels {
+		ratio, err := ContrastRatio(l.Color)
+		if err != nil {
+			// Invalid colors are reported by ValidateLabels.
+			continue
+		}
+		if ratio < MinContrastRatio {
+			problems = append(problems, fmt.Errorf("label %q: color %s has a text contrast ratio of %.2f:1 (min %.1f:1)", l.Name, l.Color, ratio, MinContrastRatio))
+		}
+	}
+	return problems
+}
+
+// luminance returns the WCAG relative luminance of an sRGB color.
+func luminance(r, g, b float64) float64 {
+	linear := func(c float64) float64 {
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
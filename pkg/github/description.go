@@ -0,0 +1,111 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/google/go-github/github"
+)
+
+// DescriptionSupporter is implemented by providers that may turn out not to
+// support label descriptions.
+type DescriptionSupporter interface {
+	DescriptionsUnsupported() bool
+}
+
+var _ DescriptionSupporter = (*Client)(nil)
+
+// DescriptionsUnsupported reports whether the server has rejected label
+// descriptions, as older GitHub Enterprise Server versions not knowing the
+// preview media type of descriptions do. Labels are then synced by name and
+// color only.
+func (c *Client) DescriptionsUnsupported() bool {
+	return atomic.LoadInt32(&c.noDescriptions) != 0
+}
+
+// disableDescriptions reports whether err tells label descriptions are
+// unsupported, in which case further requests are sent without them. It warns
+// the first time.
+func (c *Client) disableDescriptions(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok || e.Response == nil {
+		return false
+	}
+	unsupported := e.Response.StatusCode == http.StatusUnsupportedMediaType
+	if e.Response.StatusCode == http.StatusUnprocessableEntity {
+		for _, fe := range e.Errors {
+			if fe.Field == "description" {
+				unsupported = true
+			}
+		}
+	}
+	if !unsupported {
+		return false
+	}
+	if atomic.CompareAndSwapInt32(&c.noDescriptions, 0, 1) {
+		Warnf("label descriptions unsupported by the server, syncing names and colors only: %v", err)
+	}
+	return true
+}
+
+// plainLabel is a label as known to servers not supporting descriptions.
+type plainLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// plainRequest sends a request with the default media type rather than the
+// preview one of label descriptions, decoding the response into v if not nil.
+func (c *Client) plainRequest(ctx context.Context, method, u string, body, v interface{}) (*github.Response, error) {
+	req, err := c.githubClient.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.githubClient.Do(ctx, req, v)
+}
+
+func (c *Client) listPlainLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	var labels []Label
+	for page := 1; page != 0; {
+		u := fmt.Sprintf("repos/%s/%s/labels?per_page=100&page=%d", url.PathEscape(owner), url.PathEscape(repo), page)
+		var ls []plainLabel
+		resp, err := c.plainRequest(ctx, "GET", u, nil, &ls)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range ls {
+			labels = append(labels, Label{Name: l.Name, Color: l.Color})
+		}
+		page = resp.NextPage
+	}
+	return labels, nil
+}
+
+func (c *Client) createPlainLabel(ctx context.Context, owner, repo string, label Label) error {
+	u := fmt.Sprintf("repos/%s/%s/labels", url.PathEscape(owner), url.PathEscape(repo))
+	_, err := c.plainRequest(ctx, "POST", u, &plainLabel{Name: label.Name, Color: label.Color}, nil)
+	return wrapLabelError(err)
+}
+
+func (c *Client) updatePlainLabel(ctx context.Context, owner, repo, name string, label Label) error {
+	u := fmt.Sprintf("repos/%s/%s/labels/%s", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(name))
+	_, err := c.plainRequest(ctx, "PATCH", u, &plainLabel{Name: label.Name, Color: label.Color}, nil)
+	return wrapLabelError(err)
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Collision is a label of a repository whose name differs from the name of a
+// manifest label only in ways hard to see. GitHub treats such names as the
+// same label, so creating the manifest label fails with already_exists.
+type Collision struct {
+	Name    string
+	Current string
+	// Reason tells how the names differ, e.g. "case".
+	Reason string
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("label %q collides with label %q, differing only by %s", c.Name, c.Current, c.Reason)
+}
+
+// Collisions returns the current labels colliding with labels.
+func Collisions(current, labels []Label) []Collision {
+	byKey := make(map[string]string, len(current))
+	for _, l := range current {
+		byKey[collisionKey(l.Name)] = l.Name
+	}
+	var collisions []Collision
+	for _, l := range labels {
+		cur, ok := byKey[collisionKey(l.Name)]
+		if !ok || cur == l.Name {
+			continue
+		}
+		collisions = append(collisions, Collision{
+			Name:    l.Name,
+			Current: cur,
+			Reason:  collisionReason(l.Name, cur),
+		})
+	}
+	return collisions
+}
+
+// collisionKey returns the name GitHub considers name the same label as:
+// regardless of case, surrounding spaces, invisible characters and Unicode
+// normalization, e.g. whether accented letters are precomposed.
+func collisionKey(name string) string {
+	return norm.NFC.String(strings.ToLower(strings.TrimSpace(strings.Map(dropInvisible(true, true), name))))
+}
+
+func collisionReason(a, b string) string {
+	var reasons []string
+	if strings.Map(dropInvisible(true, false), a) != a || strings.Map(dropInvisible(true, false), b) != b {
+		reasons = append(reasons, "variation selectors")
+	}
+	if strings.Map(dropInvisible(false, true), a) != a || strings.Map(dropInvisible(false, true), b) != b {
+		reasons = append(reasons, "zero-width characters")
+	}
+	if strings.TrimSpace(a) != a || strings.TrimSpace(b) != b {
+		reasons = append(reasons, "surrounding spaces")
+	}
+	if norm.NFC.String(a) != a || norm.NFC.String(b) != b {
+		reasons = append(reasons, "Unicode normalization")
+	}
+	visible := func(s string) string {
+		return norm.NFC.String(strings.TrimSpace(strings.Map(dropInvisible(true, true), s)))
+	}
+	if visible(a) != visible(b) {
+		reasons = append(reasons, "case")
+	}
+	return strings.Join(reasons, " and ")
+}
+
+// dropInvisible returns a mapping for strings.Map dropping emoji variation
+// selectors and zero-width characters as selected.
+func dropInvisible(variationSelectors, zeroWidth bool) func(rune) rune {
+	return func(r rune) rune {
+		switch r {
+		case '\uFE0E', '\uFE0F':
+			if variationSelectors {
+				return -1
+			}
+		case '\u200B', '\u200C', '\u200D', '\u2060', '\uFEFF':
+			if zeroWidth {
+				return -1
+			}
+		}
+		return r
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		label   string
+		want    []Collision
+	}{
+		{name: "same name", current: "bug", label: "bug"},
+		{name: "different name", current: "bug", label: "docs"},
+		{
+			name:    "case",
+			current: "Bug",
+			label:   "bug",
+			want:    []Collision{{Name: "bug", Current: "Bug", Reason: "case"}},
+		},
+		{
+			name:    "variation selector",
+			current: "❤️ love",
+			label:   "❤ love",
+			want:    []Collision{{Name: "❤ love", Current: "❤️ love", Reason: "variation selectors"}},
+		},
+		{
+			name:    "decomposed acute accent",
+			current: "café",
+			label:   "cafe\u0301",
+			want:    []Collision{{Name: "cafe\u0301", Current: "café", Reason: "Unicode normalization"}},
+		},
+		{
+			name:    "decomposed caron",
+			current: "ř",
+			label:   "r\u030c",
+			want:    []Collision{{Name: "r\u030c", Current: "ř", Reason: "Unicode normalization"}},
+		},
+		{
+			name:    "decomposed double acute accent",
+			current: "ő",
+			label:   "o\u030b",
+			want:    []Collision{{Name: "o\u030b", Current: "ő", Reason: "Unicode normalization"}},
+		},
+		{
+			name:    "decomposed Vietnamese",
+			current: "tiếng việt",
+			label:   "tie\u0302\u0301ng vie\u0323\u0302t",
+			want:    []Collision{{Name: "tie\u0302\u0301ng vie\u0323\u0302t", Current: "tiếng việt", Reason: "Unicode normalization"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Collisions([]Label{{Name: tt.current}}, []Label{{Name: tt.label}})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
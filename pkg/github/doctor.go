@@ -0,0 +1,96 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// Token is what the server tells about the token of a client.
+type Token struct {
+	// Login is the account of the token, or empty for tokens not acting as
+	// a user, e.g. of GitHub Apps.
+	Login string
+	// Scopes are the OAuth scopes of a classic personal access token, or nil
+	// for tokens without scopes, e.g. fine-grained ones.
+	Scopes []string
+}
+
+// HasScope reports whether t has scope, or a scope including it, or has no
+// scopes to tell.
+func (t *Token) HasScope(scope string) bool {
+	if t.Scopes == nil {
+		return true
+	}
+	for _, s := range t.Scopes {
+		if s == scope || (s == "repo" && scope == "public_repo") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIURL returns the base URL of the REST API the client calls.
+func (c *Client) APIURL() string {
+	return c.githubClient.BaseURL.String()
+}
+
+// Token returns what the server tells about the token of c, failing if the
+// token is invalid.
+func (c *Client) Token(ctx context.Context) (*Token, error) {
+	user, resp, err := c.githubClient.Users.Get(ctx, "")
+	if e, ok := err.(*github.ErrorResponse); ok && e.Response != nil && e.Response.StatusCode == http.StatusForbidden {
+		// Installation tokens of GitHub Apps can't read a user but are
+		// valid if the rate limit can be read.
+		if _, err := c.RateLimit(ctx); err != nil {
+			return nil, err
+		}
+		return &Token{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t := &Token{Login: user.GetLogin()}
+	if h, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		t.Scopes = []string{}
+		for _, s := range strings.Split(strings.Join(h, ","), ",") {
+			if s = strings.TrimSpace(s); len(s) > 0 {
+				t.Scopes = append(t.Scopes, s)
+			}
+		}
+	}
+	return t, nil
+}
+
+// CanManageLabels reports whether the token of c may create, update and delete
+// the labels of owner/repo, which takes write access, failing if it can't
+// read the repository at all.
+func (c *Client) CanManageLabels(ctx context.Context, owner, repo string) (bool, error) {
+	r, _, err := c.githubClient.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	if r.Permissions == nil {
+		// Tokens of GitHub Apps don't get permissions; assume the
+		// installation grants what it was configured with.
+		return true, nil
+	}
+	p := *r.Permissions
+	return p["push"] || p["maintain"] || p["admin"], nil
+}
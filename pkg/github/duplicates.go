@@ -0,0 +1,105 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DuplicateGroup is a set of labels that only differ by case, spacing or
+// punctuation, e.g. "wontfix" and "won't fix". Duplicates are merged into
+// Canonical.
+type DuplicateGroup struct {
+	Canonical  Label
+	Duplicates []Label
+}
+
+// FindDuplicates groups the near-duplicate labels among labels. The canonical
+// label of a group is the one whose name is in preferred, typically the
+// manifest, or else the first one by name.
+func FindDuplicates(labels []Label, preferred []Label) []DuplicateGroup {
+	isPreferred := make(map[string]bool)
+	for _, l := range preferred {
+		isPreferred[l.Name] = true
+	}
+
+	byKey := make(map[string][]Label)
+	var keys []string
+	for _, l := range labels {
+		k := duplicateKey(l.Name)
+		if len(k) == 0 {
+			continue
+		}
+		if _, ok := byKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		byKey[k] = append(byKey[k], l)
+	}
+	sort.Strings(keys)
+
+	var groups []DuplicateGroup
+	for _, k := range keys {
+		ls := byKey[k]
+		if len(ls) < 2 {
+			continue
+		}
+		sort.Slice(ls, func(i, j int) bool {
+			if isPreferred[ls[i].Name] != isPreferred[ls[j].Name] {
+				return isPreferred[ls[i].Name]
+			}
+			return ls[i].Name < ls[j].Name
+		})
+		groups = append(groups, DuplicateGroup{Canonical: ls[0], Duplicates: ls[1:]})
+	}
+	return groups
+}
+
+// duplicateKey returns name lowercased with everything but letters and digits
+// removed.
+func duplicateKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// MergeDuplicates labels every issue and pull request carrying one of the
+// duplicates of g with its canonical label and then deletes the duplicates.
+func (c *Client) MergeDuplicates(ctx context.Context, owner, repo string, g DuplicateGroup) error {
+	for _, d := range g.Duplicates {
+		issues, err := c.listIssuesWithLabel(ctx, owner, repo, d.Name, "all")
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if _, _, err := c.githubClient.Issues.AddLabelsToIssue(ctx, owner, repo, issue.GetNumber(), []string{g.Canonical.Name}); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("label: %s moved to %s on %d issue(s) on: %s/%s\n", d.Name, g.Canonical.Name, len(issues), owner, repo)
+		if err := c.DeleteLabel(ctx, owner, repo, d.Name); err != nil {
+			return err
+		}
+		fmt.Printf("label: %s deleted from: %s/%s\n", d.Name, owner, repo)
+	}
+	return nil
+}
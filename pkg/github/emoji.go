@@ -0,0 +1,154 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultEmojiPattern = "{emoji} {text}"
+
+// EmojiStyle tells where the emoji of labels go. In is "name" (the default),
+// "description" or "none", and Pattern combines the emoji with the name or
+// description through the {emoji} and {text} placeholders.
+type EmojiStyle struct {
+	In      string `yaml:"in,omitempty" json:"in,omitempty"`
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+}
+
+func (s *EmojiStyle) in() string {
+	if s == nil || len(s.In) == 0 {
+		return "name"
+	}
+	return s.In
+}
+
+func (s *EmojiStyle) pattern() string {
+	if s == nil || len(s.Pattern) == 0 {
+		return defaultEmojiPattern
+	}
+	return s.Pattern
+}
+
+// Validate returns an error if s has an unknown In or a Pattern missing a
+// placeholder.
+func (s *EmojiStyle) Validate() error {
+	switch s.in() {
+	case "name", "description", "none":
+	default:
+		return fmt.Errorf("emoji: unknown in: %s", s.In)
+	}
+	if p := s.pattern(); !strings.Contains(p, "{emoji}") || !strings.Contains(p, "{text}") {
+		return fmt.Errorf("emoji: pattern %q must contain {emoji} and {text}", p)
+	}
+	return nil
+}
+
+// RenderEmoji returns labels with their emoji combined into the name or
+// description as s tells. The labels keep their Emoji for UnrenderEmoji.
+func RenderEmoji(labels []Label, s *EmojiStyle) ([]Label, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	rendered := make([]Label, len(labels))
+	for i, l := range labels {
+		rendered[i] = l
+		if len(l.Emoji) == 0 {
+			continue
+		}
+		switch s.in() {
+		case "name":
+			rendered[i].Name = s.apply(l.Emoji, l.Name)
+			if len(l.NewName) > 0 {
+				rendered[i].NewName = s.apply(l.Emoji, l.NewName)
+			}
+		case "description":
+			rendered[i].Description = s.apply(l.Emoji, l.Description)
+		}
+	}
+	return rendered, nil
+}
+
+// UnrenderEmoji returns l, a label of a repository rendered with emoji, as
+// it's written in the manifest: with the emoji taken out of the name or
+// description.
+func UnrenderEmoji(l Label, emoji string, s *EmojiStyle) Label {
+	if len(emoji) == 0 || s.Validate() != nil {
+		return l
+	}
+	parts := strings.SplitN(s.pattern(), "{text}", 2)
+	prefix := strings.Replace(parts[0], "{emoji}", emoji, -1)
+	suffix := strings.Replace(parts[1], "{emoji}", emoji, -1)
+	strip := func(text string) string {
+		return strings.TrimSuffix(strings.TrimPrefix(text, prefix), suffix)
+	}
+
+	l.Emoji = emoji
+	switch s.in() {
+	case "name":
+		l.Name = strip(l.Name)
+	case "description":
+		l.Description = strip(l.Description)
+	}
+	return l
+}
+
+// detectEmojiRenames turns the deletion of a label and the creation of a label
+// with emoji into a rename when their names differ only by the emoji, so that
+// changing the emoji style keeps the labels on their issues.
+func detectEmojiRenames(plan *Plan) {
+	deletes := make(map[string]int)
+	for i, op := range plan.Operations {
+		if op.Kind == OperationDelete {
+			deletes[op.Label.Name] = i
+		}
+	}
+
+	drop := make(map[int]bool)
+	for i, op := range plan.Operations {
+		if op.Kind != OperationCreate || len(op.Label.Emoji) == 0 {
+			continue
+		}
+		name := withoutEmoji(op.Label.Name, op.Label.Emoji)
+		for from, d := range deletes {
+			if drop[d] || withoutEmoji(from, op.Label.Emoji) != name {
+				continue
+			}
+			previous := plan.Operations[d].Label
+			plan.Operations[i] = Operation{Kind: OperationRename, Label: op.Label, From: from, Previous: &previous}
+			drop[d] = true
+			break
+		}
+	}
+
+	ops := plan.Operations[:0]
+	for i, op := range plan.Operations {
+		if !drop[i] {
+			ops = append(ops, op)
+		}
+	}
+	plan.Operations = ops
+}
+
+// withoutEmoji returns name with emoji and the separators around it removed.
+func withoutEmoji(name, emoji string) string {
+	return strings.Trim(strings.Replace(name, emoji, "", 1), " -_:|")
+}
+
+func (s *EmojiStyle) apply(emoji, text string) string {
+	r := strings.NewReplacer("{emoji}", emoji, "{text}", text)
+	return r.Replace(s.pattern())
+}
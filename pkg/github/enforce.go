@@ -0,0 +1,93 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields tells which label fields are enforced besides the name, which
+// always is.
+type Fields struct {
+	Color       bool
+	Description bool
+}
+
+// AllFields enforces every field.
+var AllFields = Fields{Color: true, Description: true}
+
+// ParseFields parses s, either "all" or a comma-separated list of name, color
+// and description, e.g. "name,color".
+func ParseFields(s string) (Fields, error) {
+	if s == "all" {
+		return AllFields, nil
+	}
+	var f Fields
+	for _, field := range strings.Split(s, ",") {
+		switch strings.TrimSpace(field) {
+		case "name":
+		case "color":
+			f.Color = true
+		case "description":
+			f.Description = true
+		default:
+			return Fields{}, fmt.Errorf("unknown field: %s", field)
+		}
+	}
+	return f, nil
+}
+
+// WithFields makes the Syncer only enforce the fields f. The fields not
+// enforced are set on labels to create but left as they are on existing
+// labels.
+func WithFields(f Fields) SyncerOption {
+	return func(s *Syncer) {
+		s.fields = &f
+	}
+}
+
+// enforce changes the updates and renames of p to keep the current values of
+// the fields not in f, dropping the updates left with nothing to change.
+// Labels with the enforce policy are left alone.
+func (p *Plan) enforce(f Fields) {
+	current := make(map[string]Label, len(p.Current))
+	for _, l := range p.Current {
+		current[l.Name] = l
+	}
+	ops := p.Operations[:0]
+	for _, op := range p.Operations {
+		name := op.Label.Name
+		if op.Kind == OperationRename {
+			name = op.From
+		}
+		cur, ok := current[name]
+		if !ok || (op.Kind != OperationUpdate && op.Kind != OperationRename) || op.Label.Policy == PolicyEnforce {
+			ops = append(ops, op)
+			continue
+		}
+		if !f.Color {
+			op.Label.Color = cur.Color
+		}
+		if !f.Description {
+			op.Label.Description = cur.Description
+		}
+		if op.Kind == OperationUpdate && sameLabel(cur, op.Label) {
+			continue
+		}
+		ops = append(ops, op)
+	}
+	p.Operations = ops
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+// Endpoints are the URLs of the REST, upload and GraphQL APIs of a GitHub
+// server.
+type Endpoints struct {
+	API     string
+	Upload  string
+	GraphQL string
+}
+
+// ServerEndpoints derives the API endpoints of the GitHub server at serverURL,
+// e.g. https://github.com, https://octocorp.ghe.com for GitHub Enterprise Cloud
+// with data residency, or https://github.example.com for GitHub Enterprise
+// Server. The API host itself, e.g. https://api.octocorp.ghe.com, is accepted
+// as well.
+func ServerEndpoints(serverURL string) (*Endpoints, error) {
+	u, err := url.Parse(strings.TrimSuffix(serverURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("invalid server URL: %s", serverURL)
+	}
+	host := strings.ToLower(u.Host)
+	switch {
+	case host == "github.com" || host == "api.github.com":
+		return &Endpoints{
+			API:     "https://api.github.com/",
+			Upload:  "https://uploads.github.com/",
+			GraphQL: "https://api.github.com/graphql",
+		}, nil
+	case strings.HasSuffix(host, ".ghe.com"):
+		// Data residency hosts the APIs on subdomains rather than under /api
+		// as GitHub Enterprise Server does.
+		host = strings.TrimPrefix(strings.TrimPrefix(host, "api."), "uploads.")
+		return &Endpoints{
+			API:     "https://api." + host + "/",
+			Upload:  "https://uploads." + host + "/",
+			GraphQL: "https://api." + host + "/graphql",
+		}, nil
+	default:
+		base := u.Scheme + "://" + u.Host
+		return &Endpoints{
+			API:     base + "/api/v3/",
+			Upload:  base + "/api/uploads/",
+			GraphQL: base + "/api/graphql",
+		}, nil
+	}
+}
+
+// NewServerClient returns a client of the GitHub server at serverURL as
+// described by ServerEndpoints. An empty serverURL means github.com.
+func NewServerClient(serverURL, token string) (*Client, error) {
+	if len(serverURL) == 0 {
+		return NewClient(token), nil
+	}
+	ctx := context.Background()
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	return newServerClient(serverURL, oauth2.NewClient(ctx, ts))
+}
+
+// newServerClient returns a client of the GitHub server at serverURL making
+// requests with tc.
+func newServerClient(serverURL string, tc *http.Client) (*Client, error) {
+	e, err := ServerEndpoints(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	gc, err := github.NewEnterpriseClient(e.API, e.Upload, tc)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		githubClient: gc,
+		httpClient:   tc,
+		graphqlURL:   e.GraphQL,
+	}, nil
+}
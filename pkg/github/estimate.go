@@ -0,0 +1,88 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"time"
+)
+
+// callDuration is the time an API call changing a label is estimated to take,
+// as GitHub asks integrators to leave a second between mutating requests.
+const callDuration = time.Second
+
+// Estimate is the cost of applying plans.
+type Estimate struct {
+	// Calls is the number of API calls, one per operation.
+	Calls int
+	// Duration is the time the calls are estimated to take, regardless of
+	// the rate limit.
+	Duration time.Duration
+}
+
+// EstimatePlans estimates the cost of applying plans one after another, with
+// up to concurrency operations at the same time, 0 meaning no limit, as
+// ApplyPlan does: deletions first, then the other operations.
+func EstimatePlans(plans []*Plan, concurrency int) Estimate {
+	var e Estimate
+	rounds := func(n int) int {
+		if n == 0 {
+			return 0
+		}
+		if concurrency <= 0 {
+			return 1
+		}
+		return (n + concurrency - 1) / concurrency
+	}
+	for _, p := range plans {
+		deletes := 0
+		for _, op := range p.Operations {
+			if op.Kind == OperationDelete {
+				deletes++
+			}
+		}
+		e.Calls += len(p.Operations)
+		e.Duration += time.Duration(rounds(deletes)+rounds(len(p.Operations)-deletes)) * callDuration
+	}
+	return e
+}
+
+// AtRate returns e with the duration at least the time rate requests per
+// second take to make the calls, 0 meaning no limit.
+func (e Estimate) AtRate(rate float64) Estimate {
+	if rate <= 0 {
+		return e
+	}
+	if d := time.Duration(float64(e.Calls) / rate * float64(time.Second)); d > e.Duration {
+		e.Duration = d.Round(time.Second)
+	}
+	return e
+}
+
+// RateLimit is the state of the core API rate limit.
+type RateLimit struct {
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimit returns the state of the core API rate limit of the token of c.
+func (c *Client) RateLimit(ctx context.Context) (*RateLimit, error) {
+	limits, _, err := c.githubClient.RateLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	core := limits.GetCore()
+	return &RateLimit{Remaining: core.Remaining, Reset: core.Reset.Time}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import "fmt"
+
+// Provider-specific label fields.
+const (
+	FieldPriority  = "priority"
+	FieldExclusive = "exclusive"
+)
+
+// FieldSupporter is implemented by providers supporting provider-specific
+// label fields. The fields are ignored for providers that don't implement it
+// or don't support them.
+type FieldSupporter interface {
+	SupportsField(field string) bool
+}
+
+// supportedFields returns labels without the provider-specific fields
+// provider doesn't support.
+func supportedFields(provider Provider, labels []Label) []Label {
+	f, _ := provider.(FieldSupporter)
+	supports := func(field string) bool {
+		return f != nil && f.SupportsField(field)
+	}
+	stripped := make([]Label, len(labels))
+	for i, l := range labels {
+		if !supports(FieldPriority) {
+			l.Priority = nil
+		}
+		if !supports(FieldExclusive) {
+			l.Exclusive = nil
+		}
+		stripped[i] = l
+	}
+	return stripped
+}
+
+// sameLabel reports whether cur already has the description and color of l,
+// and the provider-specific fields set on l. Descriptions are compared as
+// GitHub stores them.
+func sameLabel(cur, l Label) bool {
+	if NormalizeDescription(cur.Description) != NormalizeDescription(l.Description) || cur.Color != l.Color {
+		return false
+	}
+	return sameExtras(cur, l)
+}
+
+// sameExtras reports whether cur has the provider-specific fields set on l.
+// Fields not set on l are left alone.
+func sameExtras(cur, l Label) bool {
+	if l.Priority != nil && (cur.Priority == nil || *cur.Priority != *l.Priority) {
+		return false
+	}
+	if l.Exclusive != nil && (cur.Exclusive == nil || *cur.Exclusive != *l.Exclusive) {
+		return false
+	}
+	return true
+}
+
+// extraChanges returns the provider-specific fields changed from previous to
+// l as "field: old → new".
+func extraChanges(previous, l Label) []string {
+	var changes []string
+	if l.Priority != nil && (previous.Priority == nil || *previous.Priority != *l.Priority) {
+		changes = append(changes, fmt.Sprintf("priority: %s → %d", optional(previous.Priority), *l.Priority))
+	}
+	if l.Exclusive != nil && (previous.Exclusive == nil || *previous.Exclusive != *l.Exclusive) {
+		old := "none"
+		if previous.Exclusive != nil {
+			old = fmt.Sprint(*previous.Exclusive)
+		}
+		changes = append(changes, fmt.Sprintf("exclusive: %s → %t", old, *l.Exclusive))
+	}
+	return changes
+}
+
+func optional(i *int) string {
+	if i == nil {
+		return "none"
+	}
+	return fmt.Sprint(*i)
+}
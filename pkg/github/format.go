@@ -0,0 +1,121 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatLabels returns the canonical manifest for labels. Labels are sorted by
+// sortBy ("name" or "group", which sorts by group and then by name), colors are
+// normalized by NormalizeColor and every value is double-quoted.
+func FormatLabels(labels []Label, sortBy string) ([]byte, error) {
+	ls := make([]Label, len(labels))
+	copy(ls, labels)
+
+	byName := func(i, j int) bool {
+		a, b := strings.ToLower(ls[i].Name), strings.ToLower(ls[j].Name)
+		if a != b {
+			return a < b
+		}
+		return ls[i].Name < ls[j].Name
+	}
+	switch sortBy {
+	case "name":
+		sort.SliceStable(ls, byName)
+	case "group":
+		sort.SliceStable(ls, func(i, j int) bool {
+			if ls[i].Group != ls[j].Group {
+				return ls[i].Group < ls[j].Group
+			}
+			return byName(i, j)
+		})
+	default:
+		return nil, fmt.Errorf("unknown sort key: %s", sortBy)
+	}
+
+	var buf bytes.Buffer
+	for _, l := range ls {
+		fmt.Fprintf(&buf, "- name: %s\n", strconv.Quote(l.Name))
+		if len(l.NewName) > 0 {
+			fmt.Fprintf(&buf, "  new_name: %s\n", strconv.Quote(l.NewName))
+		}
+		if len(l.Emoji) > 0 {
+			fmt.Fprintf(&buf, "  emoji: %s\n", strconv.Quote(l.Emoji))
+		}
+		fmt.Fprintf(&buf, "  description: %s\n", strconv.Quote(l.Description))
+		fmt.Fprintf(&buf, "  color: %s\n", strconv.Quote(NormalizeColor(l.Color)))
+		if l.Priority != nil {
+			fmt.Fprintf(&buf, "  priority: %d\n", *l.Priority)
+		}
+		if l.Exclusive != nil {
+			fmt.Fprintf(&buf, "  exclusive: %t\n", *l.Exclusive)
+		}
+		if len(l.Group) > 0 {
+			fmt.Fprintf(&buf, "  group: %s\n", strconv.Quote(l.Group))
+		}
+		if len(l.Policy) > 0 {
+			fmt.Fprintf(&buf, "  policy: %s\n", strconv.Quote(l.Policy))
+		}
+		if len(l.Sets) > 0 {
+			fmt.Fprintf(&buf, "  sets:\n")
+			for _, s := range l.Sets {
+				fmt.Fprintf(&buf, "    - %s\n", strconv.Quote(s))
+			}
+		}
+		if len(l.Descriptions) > 0 {
+			fmt.Fprintf(&buf, "  descriptions:\n")
+			for _, locale := range l.locales() {
+				fmt.Fprintf(&buf, "    %s: %s\n", strconv.Quote(locale), strconv.Quote(l.Descriptions[locale]))
+			}
+		}
+		if l.Match != nil {
+			fmt.Fprintf(&buf, "  match:\n")
+			if len(l.Match.Title) > 0 {
+				fmt.Fprintf(&buf, "    title: %s\n", strconv.Quote(l.Match.Title))
+			}
+			if len(l.Match.Body) > 0 {
+				fmt.Fprintf(&buf, "    body: %s\n", strconv.Quote(l.Match.Body))
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// NormalizeColor returns color in lowercase without a leading '#', with
+// 3-digit shorthand expanded. Invalid colors are only lowercased and trimmed.
+func NormalizeColor(color string) string {
+	if c, err := CanonicalColor(color); err == nil {
+		return c
+	}
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
+
+// CanonicalColor returns color as 6 lowercase hex digits without a leading
+// '#', expanding 3-digit shorthand such as "f00" to "ff0000".
+func CanonicalColor(color string) (string, error) {
+	c := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+	if shortColorRegexp.MatchString(c) {
+		c = string([]byte{c[0], c[0], c[1], c[1], c[2], c[2]})
+	}
+	if !colorRegexp.MatchString(c) {
+		return "", fmt.Errorf("color %q is neither 6 nor 3 hex digits", color)
+	}
+	return c, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"strings"
+)
+
+const isTemplateQuery = `query($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) { isTemplate }
+}`
+
+const templateRepositoriesQuery = `query($owner: String!, $after: String) {
+  repositoryOwner(login: $owner) {
+    repositories(first: 100, after: $after) {
+      nodes {
+        name
+        isArchived
+        templateRepository { nameWithOwner }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// IsTemplate reports whether owner/repo is a template repository.
+func (c *Client) IsTemplate(ctx context.Context, owner, repo string) (bool, error) {
+	var data struct {
+		Repository struct {
+			IsTemplate bool `json:"isTemplate"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
+	if err := c.graphql(ctx, isTemplateQuery, vars, &data); err != nil {
+		return false, err
+	}
+	return data.Repository.IsTemplate, nil
+}
+
+// GeneratedRepositories returns the names of the repositories of owner
+// generated from the template repository owner/repo, archived ones aside.
+// Repositories generated into other accounts can't be discovered.
+func (c *Client) GeneratedRepositories(ctx context.Context, owner, repo string) ([]string, error) {
+	vars := map[string]interface{}{
+		"owner": owner,
+	}
+	var names []string
+	for {
+		var data struct {
+			RepositoryOwner struct {
+				Repositories struct {
+					Nodes []struct {
+						Name               string `json:"name"`
+						IsArchived         bool   `json:"isArchived"`
+						TemplateRepository *struct {
+							NameWithOwner string `json:"nameWithOwner"`
+						} `json:"templateRepository"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"repositories"`
+			} `json:"repositoryOwner"`
+		}
+		if err := c.graphql(ctx, templateRepositoriesQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		rs := data.RepositoryOwner.Repositories
+		for _, r := range rs.Nodes {
+			if r.IsArchived || r.TemplateRepository == nil || !strings.EqualFold(r.TemplateRepository.NameWithOwner, owner+"/"+repo) {
+				continue
+			}
+			names = append(names, r.Name)
+		}
+		if !rs.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = rs.PageInfo.EndCursor
+	}
+	return names, nil
+}
@@ -17,33 +17,145 @@ package github
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
-	"golang.org/x/sync/errgroup"
-	"gopkg.in/yaml.v2"
 )
 
 type Client struct {
 	githubClient *github.Client
-	token        string
+	// httpClient sends the requests of githubClient, authenticated.
+	httpClient *http.Client
+	token      string
+	// graphqlURL is the GraphQL endpoint if it isn't at graphql relative to
+	// the REST API.
+	graphqlURL string
+	// noDescriptions is set once the server has rejected label descriptions.
+	noDescriptions int32
 }
 
 type Label struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Color       string `yaml:"color"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Color       string `yaml:"color" json:"color"`
+	Group       string `yaml:"group,omitempty" json:"group,omitempty"`
+	// Emoji is added to the name or description as the emoji style of the
+	// manifest tells.
+	Emoji string `yaml:"emoji,omitempty" json:"emoji,omitempty"`
+	Match *Match `yaml:"match,omitempty" json:"match,omitempty"`
+	// Descriptions holds translations of Description keyed by locale.
+	Descriptions map[string]string `yaml:"descriptions,omitempty" json:"descriptions,omitempty"`
+	// Policy overrides how the label is synced: "create-only" creates it if
+	// missing but never updates it, and "enforce" enforces every field
+	// regardless of WithFields.
+	Policy string `yaml:"policy,omitempty" json:"policy,omitempty"`
+	// NewName renames the label once. After the rename is applied, Name
+	// should be set to NewName and NewName removed.
+	NewName string `yaml:"new_name,omitempty" json:"new_name,omitempty"`
+	// Priority is the GitLab label priority, lower being more important.
+	Priority *int `yaml:"priority,omitempty" json:"priority,omitempty"`
+	// Exclusive makes a Gitea scoped label, e.g. "kind/bug", exclusive of the
+	// other labels of its scope.
+	Exclusive *bool `yaml:"exclusive,omitempty" json:"exclusive,omitempty"`
+	// Sets are the named sets the label is in. Repositories selecting sets
+	// only get the labels of those sets and the labels without sets.
+	Sets []string `yaml:"sets,omitempty" json:"sets,omitempty"`
+
+	// from is the name of the label to rename to Name, set from NewName when
+	// the manifest is loaded.
+	from string
+}
+
+const (
+	PolicyCreateOnly = "create-only"
+	PolicyEnforce    = "enforce"
+)
+
+// locales returns the locales of the translated descriptions of l in order.
+func (l *Label) locales() []string {
+	locales := make([]string, 0, len(l.Descriptions))
+	for locale := range l.Descriptions {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// String prints l for log lines: its name, description and color, and the
+// other fields only if they are set, with pointers dereferenced so that the
+// output is stable.
+func (l Label) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "{Name:%s Description:%s Color:%s", l.Name, l.Description, l.Color)
+	if len(l.Group) > 0 {
+		fmt.Fprintf(&b, " Group:%s", l.Group)
+	}
+	if len(l.Emoji) > 0 {
+		fmt.Fprintf(&b, " Emoji:%s", l.Emoji)
+	}
+	if l.Match != nil {
+		fmt.Fprintf(&b, " Match:%s", l.Match)
+	}
+	if len(l.Descriptions) > 0 {
+		b.WriteString(" Descriptions:map[")
+		for i, locale := range l.locales() {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "%s:%s", locale, l.Descriptions[locale])
+		}
+		b.WriteString("]")
+	}
+	if len(l.Policy) > 0 {
+		fmt.Fprintf(&b, " Policy:%s", l.Policy)
+	}
+	if len(l.NewName) > 0 {
+		fmt.Fprintf(&b, " NewName:%s", l.NewName)
+	}
+	if l.Priority != nil {
+		fmt.Fprintf(&b, " Priority:%d", *l.Priority)
+	}
+	if l.Exclusive != nil {
+		fmt.Fprintf(&b, " Exclusive:%t", *l.Exclusive)
+	}
+	if len(l.Sets) > 0 {
+		fmt.Fprintf(&b, " Sets:%s", strings.Join(l.Sets, ","))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// RenamedFrom returns the name of the label to rename to l.Name, as the
+// manifest tells with new_name, or an empty string.
+func (l Label) RenamedFrom() string {
+	return l.from
+}
+
+// Localize returns labels with the descriptions translated to locale. Labels
+// without a translation keep their description.
+func Localize(labels []Label, locale string) []Label {
+	if len(locale) == 0 {
+		return labels
+	}
+	localized := make([]Label, len(labels))
+	for i, l := range labels {
+		localized[i] = l
+		if d, ok := l.Descriptions[locale]; ok {
+			localized[i].Description = d
+		}
+	}
+	return localized
 }
 
 func FromManifestToLabels(path string) ([]Label, error) {
-	buf, err := ioutil.ReadFile(path)
+	m, err := LoadManifest(path)
 	if err != nil {
 		return nil, err
 	}
-	var labels []Label
-	err = yaml.Unmarshal(buf, &labels)
-	return labels, err
+	return m.Labels, nil
 }
 
 func NewClient(token string) *Client {
@@ -54,81 +166,30 @@ func NewClient(token string) *Client {
 	tc := oauth2.NewClient(ctx, ts)
 	return &Client{
 		githubClient: github.NewClient(tc),
+		httpClient:   tc,
 	}
 }
 
+// SyncLabels syncs the labels of owner/repo with labels. If prune is true,
+// labels not in labels are deleted.
 func (c *Client) SyncLabels(ctx context.Context, owner, repo string, labels []Label, prune bool) error {
-	labelMap := make(map[string]Label)
-	for _, l := range labels {
-		labelMap[l.Name] = l
-	}
-
-	currentLabels, err := c.getLabels(ctx, owner, repo)
-	if err != nil {
-		return err
-	}
-	currentLabelMap := make(map[string]Label)
-	for _, l := range currentLabels {
-		currentLabelMap[l.Name] = l
-	}
-
-	eg := errgroup.Group{}
-
-	// Delete labels.
-	if prune {
-		for _, currentLabel := range currentLabels {
-			currentLabel := currentLabel
-			eg.Go(func() error {
-				_, ok := labelMap[currentLabel.Name]
-				if ok {
-					return nil
-				}
-				return c.deleteLabel(ctx, owner, repo, currentLabel.Name)
-			})
-		}
-
-		if err := eg.Wait(); err != nil {
-			return err
-		}
-	}
-
-	// Create and/or update labels.
-	for _, l := range labels {
-		l := l
-		eg.Go(func() error {
-			currentLabel, ok := currentLabelMap[l.Name]
-			if !ok {
-				return c.createLabel(ctx, owner, repo, l)
-			}
-			if currentLabel.Description != l.Description || currentLabel.Color != l.Color {
-				return c.updateLabel(ctx, owner, repo, l)
-			}
-			fmt.Printf("label: %+v not changed on %s/%s\n", l, owner, repo)
-			return nil
-		})
-	}
-
-	return eg.Wait()
+	return NewSyncer(c).SyncLabels(ctx, owner, repo, labels, prune)
 }
 
-func (c *Client) createLabel(ctx context.Context, owner, repo string, label Label) error {
-	l := &github.Label{
-		Name:        &label.Name,
-		Description: &label.Description,
-		Color:       &label.Color,
+// ListLabels returns the labels of owner/repo.
+func (c *Client) ListLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	if c.DescriptionsUnsupported() {
+		return c.listPlainLabels(ctx, owner, repo)
 	}
-	_, _, err := c.githubClient.Issues.CreateLabel(ctx, owner, repo, l)
-	fmt.Printf("label: %+v created on: %s/%s\n", label, owner, repo)
-	return err
-}
-
-func (c *Client) getLabels(ctx context.Context, owner, repo string) ([]Label, error) {
 	opt := &github.ListOptions{
 		PerPage: 50,
 	}
 	var labels []Label
 	for {
 		ls, resp, err := c.githubClient.Issues.ListLabels(ctx, owner, repo, opt)
+		if c.disableDescriptions(err) {
+			return c.listPlainLabels(ctx, owner, repo)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -147,19 +208,68 @@ func (c *Client) getLabels(ctx context.Context, owner, repo string) ([]Label, er
 	return labels, nil
 }
 
-func (c *Client) updateLabel(ctx context.Context, owner, repo string, label Label) error {
+// CreateLabel creates label on owner/repo.
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, label Label) error {
+	if c.DescriptionsUnsupported() {
+		return c.createPlainLabel(ctx, owner, repo, label)
+	}
 	l := &github.Label{
 		Name:        &label.Name,
 		Description: &label.Description,
 		Color:       &label.Color,
 	}
-	_, _, err := c.githubClient.Issues.EditLabel(ctx, owner, repo, label.Name, l)
-	fmt.Printf("label %+v updated on: %s/%s\n", label, owner, repo)
+	_, _, err := c.githubClient.Issues.CreateLabel(ctx, owner, repo, l)
+	if c.disableDescriptions(err) {
+		return c.createPlainLabel(ctx, owner, repo, label)
+	}
+	return wrapLabelError(err)
+}
+
+// wrapLabelError wraps err with ErrAlreadyExists or ErrNotFound if it tells
+// so.
+func wrapLabelError(err error) error {
+	switch {
+	case isAlreadyExists(err):
+		return fmt.Errorf("%w: %v", ErrAlreadyExists, err)
+	case isNotFound(err):
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
 	return err
 }
 
-func (c *Client) deleteLabel(ctx context.Context, owner, repo, name string) error {
+func isAlreadyExists(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	if !ok {
+		return false
+	}
+	for _, fe := range e.Errors {
+		if fe.Code == "already_exists" {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateLabel updates the label name on owner/repo to label, renaming it if
+// the names differ.
+func (c *Client) UpdateLabel(ctx context.Context, owner, repo, name string, label Label) error {
+	if c.DescriptionsUnsupported() {
+		return c.updatePlainLabel(ctx, owner, repo, name, label)
+	}
+	l := &github.Label{
+		Name:        &label.Name,
+		Description: &label.Description,
+		Color:       &label.Color,
+	}
+	_, _, err := c.githubClient.Issues.EditLabel(ctx, owner, repo, name, l)
+	if c.disableDescriptions(err) {
+		return c.updatePlainLabel(ctx, owner, repo, name, label)
+	}
+	return wrapLabelError(err)
+}
+
+// DeleteLabel deletes the label name from owner/repo.
+func (c *Client) DeleteLabel(ctx context.Context, owner, repo, name string) error {
 	_, err := c.githubClient.Issues.DeleteLabel(ctx, owner, repo, name)
-	fmt.Printf("label: %s deleted from: %s/%s\n", name, owner, repo)
-	return err
+	return wrapLabelError(err)
 }
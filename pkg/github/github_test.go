@@ -0,0 +1,53 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import "testing"
+
+func TestLabelString(t *testing.T) {
+	priority, exclusive := 2, true
+	tests := []struct {
+		name  string
+		label Label
+		want  string
+	}{
+		{
+			name:  "unset fields are left out",
+			label: Label{Name: "bug", Description: "Something isn't working", Color: "d73a4a"},
+			want:  "{Name:bug Description:Something isn't working Color:d73a4a}",
+		},
+		{
+			name: "pointers are dereferenced",
+			label: Label{
+				Name:         "kind/bug",
+				Color:        "d73a4a",
+				Descriptions: map[string]string{"ja": "バグ", "de": "Fehler"},
+				Priority:     &priority,
+				Exclusive:    &exclusive,
+				Sets:         []string{"core", "web"},
+			},
+			want: "{Name:kind/bug Description: Color:d73a4a Descriptions:map[de:Fehler ja:バグ] Priority:2 Exclusive:true Sets:core,web}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.label.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphql runs query with variables against the GraphQL API and decodes the
+// data of the response into v if not nil.
+func (c *Client) graphql(ctx context.Context, query string, variables map[string]interface{}, v interface{}) error {
+	endpoint := c.graphqlURL
+	if len(endpoint) == 0 {
+		endpoint = "graphql"
+	}
+	req, err := c.githubClient.NewRequest("POST", endpoint, &graphqlRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return err
+	}
+	var resp graphqlResponse
+	if _, err := c.githubClient.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, v)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/google/go-github/github"
+)
+
+// listIssuesWithLabel returns the issues and pull requests of owner/repo in
+// state ("open", "closed" or "all") that are labeled with label.
+func (c *Client) listIssuesWithLabel(ctx context.Context, owner, repo, label, state string) ([]*github.Issue, error) {
+	opt := &github.IssueListByRepoOptions{
+		State:  state,
+		Labels: []string{label},
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	var issues []*github.Issue
+	for {
+		is, resp, err := c.githubClient.Issues.ListByRepo(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, is...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// AffectedIssue is an open issue or pull request losing a label to a deletion
+// or a rename.
+type AffectedIssue struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Label      string `json:"label"`
+}
+
+// AffectedIssues returns the open issues and pull requests having a label
+// plan deletes or renames. It has to be called before plan is applied.
+func (c *Client) AffectedIssues(ctx context.Context, plan *Plan) ([]AffectedIssue, error) {
+	var affected []AffectedIssue
+	for _, op := range plan.Operations {
+		var name string
+		switch op.Kind {
+		case OperationDelete:
+			name = op.Label.Name
+		case OperationRename:
+			name = op.From
+		default:
+			continue
+		}
+		issues, err := c.listIssuesWithLabel(ctx, plan.Owner, plan.Repo, name, "open")
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			affected = append(affected, AffectedIssue{
+				Repository: plan.Owner + "/" + plan.Repo,
+				Number:     issue.GetNumber(),
+				Title:      issue.GetTitle(),
+				Label:      name,
+			})
+		}
+	}
+	return affected, nil
+}
+
+// WriteAffectedIssues writes issues as CSV to path.
+func WriteAffectedIssues(path string, issues []AffectedIssue) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"repository", "number", "title", "removed_label"})
+	for _, i := range issues {
+		w.Write([]string{i.Repository, strconv.Itoa(i.Number), i.Title, i.Label})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
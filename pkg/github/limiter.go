@@ -0,0 +1,86 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limiter is a RoundTripper letting through at most Rate requests per second
+// on average, in bursts of up to Burst requests. It is a token bucket shared
+// by every client using it, so a run over many repositories stays under the
+// limits of the server as a whole rather than per repository.
+type Limiter struct {
+	Transport http.RoundTripper
+	Rate      float64
+	Burst     int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+var _ http.RoundTripper = (*Limiter)(nil)
+
+// NewLimiter returns a limiter of rate requests per second in front of
+// transport, or http.DefaultTransport if nil. The burst is the rate rounded
+// up, so that a second's worth of requests can go at once.
+func NewLimiter(transport http.RoundTripper, rate float64) *Limiter {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	burst := int(rate)
+	if float64(burst) < rate {
+		burst++
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{Transport: transport, Rate: rate, Burst: burst, tokens: float64(burst)}
+}
+
+// RoundTrip waits for a token, or the context of req to be done, and sends
+// req.
+func (l *Limiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	t := time.NewTimer(l.reserve(time.Now()))
+	defer t.Stop()
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-t.C:
+	}
+	return l.Transport.RoundTrip(req)
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+// The bucket may go below zero, queuing waiters in order.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.Rate
+		if l.tokens > float64(l.Burst) {
+			l.tokens = float64(l.Burst)
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.Rate * float64(time.Second))
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var linkRegexp = regexp.MustCompile(`https?://[^\s<>"'()]+`)
+
+// DescriptionLinks returns the URLs in the descriptions of labels, including
+// translated ones, mapped to the names of the labels linking to them. URLs in
+// templates are skipped as they depend on the repository.
+func DescriptionLinks(labels []Label) map[string][]string {
+	links := make(map[string][]string)
+	add := func(name, desc string) {
+		if strings.Contains(desc, "{{") {
+			return
+		}
+		for _, u := range linkRegexp.FindAllString(desc, -1) {
+			u = strings.TrimRight(u, ".,;:!?")
+			if n := len(links[u]); n == 0 || links[u][n-1] != name {
+				links[u] = append(links[u], name)
+			}
+		}
+	}
+	for _, l := range labels {
+		add(l.Name, l.Description)
+		for _, locale := range l.locales() {
+			add(l.Name, l.Descriptions[locale])
+		}
+	}
+	return links
+}
+
+// CheckLink returns an error unless url responds with a 2xx status. Servers
+// not supporting HEAD are asked with GET.
+func CheckLink(ctx context.Context, client *http.Client, url string) error {
+	resp, err := linkRequest(ctx, client, http.MethodHead, url)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, err = linkRequest(ctx, client, http.MethodGet, url)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded with %s", url, resp.Status)
+	}
+	return nil
+}
+
+func linkRequest(ctx context.Context, client *http.Client, method, url string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// lockTTL is how long a lock is held at most, so that a lock left by a
+	// crashed run doesn't block syncs forever.
+	lockTTL = 30 * time.Minute
+	// lockPollInterval is how often a held lock is checked while waiting.
+	lockPollInterval = 10 * time.Second
+
+	lockColor  = "ededed"
+	lockPrefix = "action-label-syncer lock until "
+)
+
+// WithLock makes the Syncer lock repositories with a marker label named name,
+// waiting up to timeout for locks held by others. The marker label is never
+// planned to be changed.
+func WithLock(name string, timeout time.Duration) SyncerOption {
+	return func(s *Syncer) {
+		s.lockLabel = name
+		s.lockTimeout = timeout
+	}
+}
+
+// IsLockMarker reports whether l is the marker label of an advisory lock,
+// whatever the name of the lock label.
+func IsLockMarker(l Label) bool {
+	return strings.HasPrefix(l.Description, lockPrefix)
+}
+
+// Lock takes the advisory lock of owner/repo so that concurrent syncs don't
+// interleave, and returns the function releasing it. It does nothing without
+// WithLock.
+func (s *Syncer) Lock(ctx context.Context, owner, repo string) (func() error, error) {
+	if len(s.lockLabel) == 0 {
+		return func() error { return nil }, nil
+	}
+
+	deadline := time.Now().Add(s.lockTimeout)
+	for {
+		now := time.Now()
+		marker := Label{
+			Name:        s.lockLabel,
+			Description: lockPrefix + now.Add(lockTTL).UTC().Format(time.RFC3339),
+			Color:       lockColor,
+		}
+		err := s.provider.CreateLabel(ctx, owner, repo, marker)
+		if err == nil {
+			fmt.Printf("lock: %s taken on: %s/%s\n", s.lockLabel, owner, repo)
+			return func() error {
+				if err := s.provider.DeleteLabel(context.Background(), owner, repo, s.lockLabel); err != nil && !errors.Is(err, ErrNotFound) {
+					return fmt.Errorf("unable to release lock: %w", err)
+				}
+				fmt.Printf("lock: %s released on: %s/%s\n", s.lockLabel, owner, repo)
+				return nil
+			}, nil
+		}
+		if !errors.Is(err, ErrAlreadyExists) {
+			return nil, fmt.Errorf("unable to take lock: %w", err)
+		}
+
+		expired, err := s.lockExpired(ctx, owner, repo, now)
+		if err != nil {
+			return nil, fmt.Errorf("unable to take lock: %w", err)
+		}
+		if expired {
+			fmt.Printf("lock: %s expired on: %s/%s, breaking it\n", s.lockLabel, owner, repo)
+			if err := s.provider.DeleteLabel(ctx, owner, repo, s.lockLabel); err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, fmt.Errorf("unable to break lock: %w", err)
+			}
+			continue
+		}
+		if now.After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock %s on: %s/%s", s.lockLabel, owner, repo)
+		}
+		fmt.Printf("lock: %s held on: %s/%s, waiting\n", s.lockLabel, owner, repo)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// lockExpired reports whether the marker label of owner/repo has outlived its
+// TTL. A marker without an expiry, e.g. created by hand, never expires.
+func (s *Syncer) lockExpired(ctx context.Context, owner, repo string, now time.Time) (bool, error) {
+	labels, err := s.provider.ListLabels(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	for _, l := range labels {
+		if l.Name != s.lockLabel || !strings.HasPrefix(l.Description, lockPrefix) {
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, strings.TrimPrefix(l.Description, lockPrefix))
+		return err == nil && now.After(until), nil
+	}
+	return false, nil
+}
+
+// listLabels returns the labels of owner/repo except the lock marker, cached
+// if configured.
+func (s *Syncer) listLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	labels, ok := s.cache.get(owner, repo)
+	if !ok {
+		what := fmt.Sprintf("listing labels on: %s/%s", owner, repo)
+		err := s.call(ctx, os.Stdout, what, func(ctx context.Context) error {
+			var err error
+			labels, err = s.provider.ListLabels(ctx, owner, repo)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.cache.put(owner, repo, labels)
+	}
+	if len(s.lockLabel) == 0 {
+		return labels, nil
+	}
+	filtered := labels[:0]
+	for _, l := range labels {
+		if l.Name != s.lockLabel {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered, nil
+}
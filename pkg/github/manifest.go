@@ -0,0 +1,136 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest is the content of a manifest file. A manifest is either a list of
+// labels or a mapping with a labels key and optional sections next to it.
+type Manifest struct {
+	Labels []Label `yaml:"labels"`
+	// Emoji tells where the emoji of labels go.
+	Emoji *EmojiStyle `yaml:"emoji,omitempty"`
+	// Milestones are only synced when the section is present.
+	Milestones []Milestone `yaml:"milestones,omitempty"`
+	// Config holds the other keys, which make the manifest a config file
+	// of inputs too. They're validated by the command.
+	Config map[string]interface{} `yaml:",inline"`
+}
+
+// LoadManifest reads the manifest at path with the emoji of its labels
+// rendered into their names or descriptions, and labels with a new name
+// renamed.
+func LoadManifest(path string) (*Manifest, error) {
+	m, err := readManifest(path, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.render(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// render turns the labels of m into the labels to sync: with their emoji
+// rendered and renamed to their NewName.
+func (m *Manifest) render() error {
+	labels, err := RenderEmoji(m.Labels, m.Emoji)
+	if err != nil {
+		return err
+	}
+	for i, l := range labels {
+		if len(l.NewName) > 0 {
+			labels[i].Name, labels[i].NewName, labels[i].from = l.NewName, "", l.Name
+		}
+	}
+	m.Labels = labels
+	return nil
+}
+
+// ParseManifest reads the manifest at path as it's written, rejecting unknown
+// fields. Unlike LoadManifest, it doesn't render the emoji of labels or rename
+// them.
+func ParseManifest(path string) (*Manifest, error) {
+	return readManifest(path, true)
+}
+
+func readManifest(path string, strict bool) (*Manifest, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	unmarshal := yaml.Unmarshal
+	if strict {
+		unmarshal = yaml.UnmarshalStrict
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(buf, &v); err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if _, ok := v.([]interface{}); ok || v == nil {
+		err = unmarshal(buf, &m.Labels)
+	} else {
+		err = unmarshal(buf, m)
+	}
+	return m, err
+}
+
+// FormatManifest returns the canonical form of m as FormatLabels does for its
+// labels. A manifest with labels only is formatted as a list of labels.
+func FormatManifest(m *Manifest, sortBy string) ([]byte, error) {
+	labels, err := FormatLabels(m.Labels, sortBy)
+	if err != nil || (m.Milestones == nil && m.Emoji == nil && len(m.Config) == 0) {
+		return labels, err
+	}
+
+	var buf bytes.Buffer
+	if len(m.Config) > 0 {
+		config, err := yaml.Marshal(m.Config)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(config)
+	}
+	if m.Emoji != nil {
+		buf.WriteString("emoji:\n")
+		if len(m.Emoji.In) > 0 {
+			fmt.Fprintf(&buf, "  in: %s\n", strconv.Quote(m.Emoji.In))
+		}
+		if len(m.Emoji.Pattern) > 0 {
+			fmt.Fprintf(&buf, "  pattern: %s\n", strconv.Quote(m.Emoji.Pattern))
+		}
+	}
+	buf.WriteString("labels:\n")
+	for _, line := range bytes.SplitAfter(labels, []byte("\n")) {
+		if len(line) > 0 {
+			buf.WriteString("  ")
+			buf.Write(line)
+		}
+	}
+	if m.Milestones != nil {
+		buf.WriteString("milestones:\n")
+		buf.Write(formatMilestones(m.Milestones))
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown returns p as the section of the job summary written by the merge
+// mode: a heading with the repository and a table of the operations.
+func (p *Plan) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s/%s: %d operation(s)\n\n", p.Owner, p.Repo, len(p.Operations))
+	if len(p.Operations) == 0 {
+		return b.String()
+	}
+	b.WriteString("| Operation | Label | Color | Description |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, op := range p.Operations {
+		name := "`" + op.Label.Name + "`"
+		if op.Kind == OperationRename {
+			name = "`" + op.From + "` → " + name
+		}
+		fmt.Fprintf(&b, "| %s | %s | `%s` | %s |\n", op.Kind, escapeCell(name), op.Label.Color, escapeCell(op.Label.Description))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// MarkdownReport returns plans as the job summary written by the merge mode.
+func MarkdownReport(plans []*Plan) string {
+	var b strings.Builder
+	b.WriteString("## Label plan\n\n")
+	for _, p := range plans {
+		b.WriteString(p.Markdown())
+	}
+	return b.String()
+}
+
+// escapeCell escapes the pipes of s, which would end a table cell.
+func escapeCell(s string) string {
+	return strings.Replace(s, "|", "\\|", -1)
+}
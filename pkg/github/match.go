@@ -0,0 +1,104 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/github"
+)
+
+// Match holds the rules labeling issues automatically. An issue matches if
+// any of the regular expressions matches.
+type Match struct {
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+	Body  string `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// String prints m by value, so that labels logged with %+v don't show the
+// address of their match rules.
+func (m *Match) String() string {
+	if m == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{Title:%s Body:%s}", m.Title, m.Body)
+}
+
+type matcher struct {
+	title, body *regexp.Regexp
+}
+
+func (m *Match) compile() (*matcher, error) {
+	var (
+		mt  matcher
+		err error
+	)
+	if len(m.Title) > 0 {
+		if mt.title, err = regexp.Compile(m.Title); err != nil {
+			return nil, fmt.Errorf("invalid title match: %w", err)
+		}
+	}
+	if len(m.Body) > 0 {
+		if mt.body, err = regexp.Compile(m.Body); err != nil {
+			return nil, fmt.Errorf("invalid body match: %w", err)
+		}
+	}
+	return &mt, nil
+}
+
+// MatchLabels returns the names of the labels whose match rules match an issue
+// with title and body.
+func MatchLabels(labels []Label, title, body string) ([]string, error) {
+	var names []string
+	for _, l := range labels {
+		if l.Match == nil {
+			continue
+		}
+		m, err := l.Match.compile()
+		if err != nil {
+			return nil, fmt.Errorf("label %q: %w", l.Name, err)
+		}
+		if (m.title != nil && m.title.MatchString(title)) || (m.body != nil && m.body.MatchString(body)) {
+			names = append(names, l.Name)
+		}
+	}
+	return names, nil
+}
+
+// GetIssue returns the title and body of the issue or pull request number of
+// owner/repo.
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, number int) (string, string, error) {
+	issue, _, err := c.githubClient.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return "", "", err
+	}
+	return issue.GetTitle(), issue.GetBody(), nil
+}
+
+// AddLabels adds the labels names to the issue or pull request number of
+// owner/repo.
+func (c *Client) AddLabels(ctx context.Context, owner, repo string, number int, names []string) error {
+	_, _, err := c.githubClient.Issues.AddLabelsToIssue(ctx, owner, repo, number, names)
+	return err
+}
+
+// CreateComment comments body on the issue or pull request number of
+// owner/repo.
+func (c *Client) CreateComment(ctx context.Context, owner, repo string, number int, body string) error {
+	_, _, err := c.githubClient.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+	return err
+}
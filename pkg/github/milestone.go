@@ -0,0 +1,206 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"go.uber.org/multierr"
+)
+
+const dueOnLayout = "2006-01-02"
+
+type Milestone struct {
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// DueOn is a date in the form of 2006-01-02.
+	DueOn string `yaml:"due_on,omitempty" json:"due_on,omitempty"`
+	// State is either "open", the default, or "closed".
+	State string `yaml:"state,omitempty" json:"state,omitempty"`
+
+	number int
+}
+
+// MilestoneOperation is a single change to the milestones of a repository.
+type MilestoneOperation struct {
+	Kind      OperationKind
+	Milestone Milestone
+}
+
+func (m Milestone) state() string {
+	if len(m.State) == 0 {
+		return "open"
+	}
+	return m.State
+}
+
+// ValidateMilestones returns every problem found in milestones combined into
+// a single error, or nil if the milestones are valid.
+func ValidateMilestones(milestones []Milestone) error {
+	var err error
+	seen := make(map[string]bool)
+	for i, m := range milestones {
+		if len(m.Title) == 0 {
+			err = multierr.Append(err, fmt.Errorf("milestone #%d: title is required", i+1))
+			continue
+		}
+		if seen[m.Title] {
+			err = multierr.Append(err, fmt.Errorf("milestone %q: duplicated", m.Title))
+		}
+		seen[m.Title] = true
+		if len(m.DueOn) > 0 {
+			if _, e := time.Parse(dueOnLayout, m.DueOn); e != nil {
+				err = multierr.Append(err, fmt.Errorf("milestone %q: due_on %q must be a date like 2006-01-02", m.Title, m.DueOn))
+			}
+		}
+		if s := m.state(); s != "open" && s != "closed" {
+			err = multierr.Append(err, fmt.Errorf("milestone %q: state %q must be open or closed", m.Title, m.State))
+		}
+	}
+	return err
+}
+
+// PlanMilestones returns the operations to sync the milestones of owner/repo
+// with milestones, matched by title. If prune is true, milestones not in
+// milestones are planned to be deleted.
+func (c *Client) PlanMilestones(ctx context.Context, owner, repo string, milestones []Milestone, prune bool) ([]MilestoneOperation, error) {
+	current, err := c.getMilestones(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	currentMap := make(map[string]Milestone)
+	for _, m := range current {
+		currentMap[m.Title] = m
+	}
+	milestoneMap := make(map[string]bool)
+	for _, m := range milestones {
+		milestoneMap[m.Title] = true
+	}
+
+	var ops []MilestoneOperation
+	if prune {
+		for _, m := range current {
+			if !milestoneMap[m.Title] {
+				ops = append(ops, MilestoneOperation{Kind: OperationDelete, Milestone: m})
+			}
+		}
+	}
+	for _, m := range milestones {
+		cur, ok := currentMap[m.Title]
+		if !ok {
+			ops = append(ops, MilestoneOperation{Kind: OperationCreate, Milestone: m})
+			continue
+		}
+		if cur.Description != m.Description || cur.DueOn != m.DueOn || cur.state() != m.state() {
+			m.number = cur.number
+			ops = append(ops, MilestoneOperation{Kind: OperationUpdate, Milestone: m})
+		}
+	}
+	return ops, nil
+}
+
+// ApplyMilestones executes ops on owner/repo.
+func (c *Client) ApplyMilestones(ctx context.Context, owner, repo string, ops []MilestoneOperation) error {
+	for _, op := range ops {
+		m := op.Milestone
+		var err error
+		switch op.Kind {
+		case OperationCreate:
+			_, _, err = c.githubClient.Issues.CreateMilestone(ctx, owner, repo, toGitHubMilestone(m))
+		case OperationUpdate:
+			_, _, err = c.githubClient.Issues.EditMilestone(ctx, owner, repo, m.number, toGitHubMilestone(m))
+		case OperationDelete:
+			_, err = c.githubClient.Issues.DeleteMilestone(ctx, owner, repo, m.number)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("milestone: %s %sd on: %s/%s\n", m.Title, op.Kind, owner, repo)
+	}
+	return nil
+}
+
+func (c *Client) getMilestones(ctx context.Context, owner, repo string) ([]Milestone, error) {
+	opt := &github.MilestoneListOptions{
+		State: "all",
+		ListOptions: github.ListOptions{
+			PerPage: 50,
+		},
+	}
+	var milestones []Milestone
+	for {
+		ms, resp, err := c.githubClient.Issues.ListMilestones(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range ms {
+			var dueOn string
+			if m.DueOn != nil {
+				dueOn = m.DueOn.UTC().Format(dueOnLayout)
+			}
+			milestones = append(milestones, Milestone{
+				Title:       m.GetTitle(),
+				Description: m.GetDescription(),
+				DueOn:       dueOn,
+				State:       m.GetState(),
+				number:      m.GetNumber(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return milestones, nil
+}
+
+func toGitHubMilestone(m Milestone) *github.Milestone {
+	gm := &github.Milestone{
+		Title:       github.String(m.Title),
+		Description: github.String(m.Description),
+		State:       github.String(m.state()),
+	}
+	if t, err := time.Parse(dueOnLayout, m.DueOn); err == nil {
+		gm.DueOn = &t
+	}
+	return gm
+}
+
+func (op MilestoneOperation) String() string {
+	return fmt.Sprintf("milestone: %s will be %sd", op.Milestone.Title, op.Kind)
+}
+
+func formatMilestones(milestones []Milestone) []byte {
+	var buf bytes.Buffer
+	for _, m := range milestones {
+		fmt.Fprintf(&buf, "  - title: %s\n", strconv.Quote(m.Title))
+		if len(m.Description) > 0 {
+			fmt.Fprintf(&buf, "    description: %s\n", strconv.Quote(m.Description))
+		}
+		if len(m.DueOn) > 0 {
+			fmt.Fprintf(&buf, "    due_on: %s\n", strconv.Quote(m.DueOn))
+		}
+		if len(m.State) > 0 {
+			fmt.Fprintf(&buf, "    state: %s\n", strconv.Quote(strings.ToLower(m.State)))
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.uber.org/multierr"
+)
+
+// NamingPolicy is an organization policy on the labels of a repository.
+type NamingPolicy struct {
+	// MaxLabels is the maximum number of labels, 0 meaning no limit.
+	MaxLabels int
+	// NamePattern is what every name must match, nil meaning anything.
+	NamePattern *regexp.Regexp
+}
+
+// Validate returns an error for every label of labels breaking p, and one if
+// there are too many, combined into a single error.
+func (p *NamingPolicy) Validate(labels []Label) error {
+	var err error
+	if p.MaxLabels > 0 && len(labels) > p.MaxLabels {
+		err = multierr.Append(err, fmt.Errorf("%d labels, more than the maximum of %d", len(labels), p.MaxLabels))
+	}
+	if p.NamePattern == nil {
+		return err
+	}
+	for _, l := range labels {
+		if !p.NamePattern.MatchString(l.Name) {
+			err = multierr.Append(err, fmt.Errorf("label %q: name doesn't match %s", l.Name, p.NamePattern))
+		}
+	}
+	return err
+}
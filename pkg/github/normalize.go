@@ -0,0 +1,85 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.uber.org/multierr"
+)
+
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)
+
+// WithStrictDescriptions makes the Syncer fail on descriptions GitHub would
+// store differently, or show differently, rather than warn about them.
+func WithStrictDescriptions() SyncerOption {
+	return func(s *Syncer) {
+		s.strictDescriptions = true
+	}
+}
+
+// NormalizeDescription returns description as GitHub stores it: line breaks,
+// tabs and other control characters become spaces and surrounding spaces are
+// trimmed. Comparing normalized descriptions keeps a manifest description
+// with such characters from being updated on every sync.
+func NormalizeDescription(description string) string {
+	description = strings.Replace(description, "\r\n", "\n", -1)
+	description = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, description)
+	return strings.TrimSpace(description)
+}
+
+// descriptionProblem returns what GitHub does to description, or an empty
+// string if it's stored and shown as is.
+func descriptionProblem(description string) string {
+	switch {
+	case strings.ContainsAny(description, "\r\n"):
+		return "line breaks become spaces"
+	case NormalizeDescription(description) != strings.TrimSpace(description):
+		return "control characters become spaces"
+	case description != strings.TrimSpace(description):
+		return "surrounding spaces are trimmed"
+	case htmlTag.MatchString(description):
+		return "HTML is shown as is, not rendered"
+	}
+	return ""
+}
+
+// normalizeDescriptions returns labels with their descriptions normalized,
+// warning about the descriptions changed or containing HTML, or failing on
+// them with WithStrictDescriptions.
+func (s *Syncer) normalizeDescriptions(owner, repo string, labels []Label) ([]Label, error) {
+	var err error
+	normalized := make([]Label, len(labels))
+	for i, l := range labels {
+		if p := descriptionProblem(l.Description); len(p) > 0 {
+			if s.strictDescriptions {
+				err = multierr.Append(err, fmt.Errorf("label %q: unsupported description: %s", l.Name, p))
+			} else {
+				Warnf("label: %s description on: %s/%s: %s", l.Name, owner, repo, p)
+			}
+		}
+		l.Description = NormalizeDescription(l.Description)
+		normalized[i] = l
+	}
+	return normalized, err
+}
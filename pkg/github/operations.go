@@ -0,0 +1,72 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseOperations parses "all" or a comma-separated list of operation kinds,
+// e.g. "create,update". "all" returns nil.
+func ParseOperations(s string) ([]OperationKind, error) {
+	if s == "all" {
+		return nil, nil
+	}
+	var kinds []OperationKind
+	for _, kind := range strings.Split(s, ",") {
+		switch k := OperationKind(strings.TrimSpace(kind)); k {
+		case OperationCreate, OperationUpdate, OperationDelete, OperationRename:
+			kinds = append(kinds, k)
+		default:
+			return nil, fmt.Errorf("unknown operation: %s", kind)
+		}
+	}
+	return kinds, nil
+}
+
+// WithOperations makes the Syncer only plan operations of kinds, e.g. to roll
+// out creations first and updates and deletions later. Nil means every kind.
+func WithOperations(kinds []OperationKind) SyncerOption {
+	return func(s *Syncer) {
+		if kinds == nil {
+			s.operations = nil
+			return
+		}
+		s.operations = make(map[OperationKind]bool, len(kinds))
+		for _, k := range kinds {
+			s.operations[k] = true
+		}
+	}
+}
+
+// dropOperations removes from p the operations not of kinds, unless kinds is
+// nil, and returns them.
+func (p *Plan) dropOperations(kinds map[OperationKind]bool) []Operation {
+	if kinds == nil {
+		return nil
+	}
+	var dropped []Operation
+	ops := p.Operations[:0]
+	for _, op := range p.Operations {
+		if !kinds[op.Kind] {
+			dropped = append(dropped, op)
+			continue
+		}
+		ops = append(ops, op)
+	}
+	p.Operations = ops
+	return dropped
+}
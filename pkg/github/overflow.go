@@ -0,0 +1,102 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/multierr"
+)
+
+// Truncation is a description cut to the length GitHub accepts.
+type Truncation struct {
+	Label string
+	// Locale is set for a translated description.
+	Locale string
+	// Cut is the number of characters kept before the ellipsis, out of
+	// Length.
+	Cut, Length int
+}
+
+func (t Truncation) String() string {
+	description := "description"
+	if len(t.Locale) > 0 {
+		description = t.Locale + " description"
+	}
+	return fmt.Sprintf("label: %s %s truncated at character %d of %d", t.Label, description, t.Cut, t.Length)
+}
+
+// TruncateDescriptions returns labels with the descriptions, and translated
+// descriptions, longer than GitHub accepts cut at the last word boundary that
+// fits and ended with an ellipsis. Description templates are left as is since
+// their length is only known once executed.
+func TruncateDescriptions(labels []Label) ([]Label, []Truncation) {
+	var truncations []Truncation
+	truncated := make([]Label, len(labels))
+	for i, l := range labels {
+		if d, cut, ok := truncateDescription(l.Description); ok {
+			truncations = append(truncations, Truncation{Label: l.Name, Cut: cut, Length: utf8.RuneCountInString(l.Description)})
+			l.Description = d
+		}
+		if len(l.Descriptions) > 0 {
+			descriptions := make(map[string]string, len(l.Descriptions))
+			for _, locale := range l.locales() {
+				d, cut, ok := truncateDescription(l.Descriptions[locale])
+				if ok {
+					truncations = append(truncations, Truncation{Label: l.Name, Locale: locale, Cut: cut, Length: utf8.RuneCountInString(l.Descriptions[locale])})
+				}
+				descriptions[locale] = d
+			}
+			l.Descriptions = descriptions
+		}
+		truncated[i] = l
+	}
+	return truncated, truncations
+}
+
+func truncateDescription(description string) (string, int, bool) {
+	rs := []rune(description)
+	if len(rs) <= maxDescriptionLength || strings.Contains(description, "{{") {
+		return description, 0, false
+	}
+	// Leave room for the ellipsis, and keep words whole unless that loses
+	// more than a fifth of the description.
+	cut := maxDescriptionLength - 1
+	for i := cut; i > cut*4/5; i-- {
+		if rs[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+	kept := strings.TrimRight(string(rs[:cut]), " ")
+	return kept + "…", utf8.RuneCountInString(kept), true
+}
+
+// ValidateTruncatedManifest is ValidateManifest with the descriptions too
+// long truncated by TruncateDescriptions rather than rejected.
+func ValidateTruncatedManifest(path string) (*Manifest, []Truncation, error) {
+	m, err := ParseManifest(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := m.render(); err != nil {
+		return nil, nil, err
+	}
+	var truncations []Truncation
+	m.Labels, truncations = TruncateDescriptions(m.Labels)
+	return m, truncations, multierr.Append(ValidateLabels(m.Labels), ValidateMilestones(m.Milestones))
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origin tells which manifest the final definition of a label comes from, and
+// the manifests of the definitions it has overridden, in order.
+type Origin struct {
+	Label      string
+	Source     string
+	Overridden []string
+}
+
+func (o Origin) String() string {
+	if len(o.Overridden) == 0 {
+		return fmt.Sprintf("label: %s from: %s", o.Label, o.Source)
+	}
+	return fmt.Sprintf("label: %s from: %s, overriding: %s", o.Label, o.Source, strings.Join(o.Overridden, ", "))
+}
+
+// LayerManifests loads the manifest at path as LoadManifest does and layers
+// the labels of the manifests at overlays on top of it in order. A label
+// defined again, regardless of case and invisible characters, replaces the
+// earlier definition in place, and new labels are appended; duplicates within
+// a manifest are merged the same way. The other sections of overlays are
+// ignored. It returns the origin of every label of the merged manifest.
+func LayerManifests(path string, overlays []string) (*Manifest, []Origin, error) {
+	m, err := LoadManifest(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		labels  []Label
+		origins []Origin
+		index   = make(map[string]int)
+	)
+	layer := func(source string, ls []Label) {
+		for _, l := range ls {
+			key := collisionKey(l.Name)
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(labels)
+				labels = append(labels, l)
+				origins = append(origins, Origin{Label: l.Name, Source: source})
+				continue
+			}
+			labels[i] = l
+			origins[i] = Origin{
+				Label:      l.Name,
+				Source:     source,
+				Overridden: append(origins[i].Overridden, origins[i].Source),
+			}
+		}
+	}
+
+	layer(path, m.Labels)
+	for _, overlay := range overlays {
+		o, err := LoadManifest(overlay)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", overlay, err)
+		}
+		layer(overlay, o.Labels)
+	}
+	m.Labels = labels
+	return m, origins, nil
+}
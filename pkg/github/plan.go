@@ -0,0 +1,720 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-github/github"
+	"go.uber.org/multierr"
+	"golang.org/x/sync/errgroup"
+)
+
+type OperationKind string
+
+const (
+	OperationCreate OperationKind = "create"
+	OperationUpdate OperationKind = "update"
+	OperationDelete OperationKind = "delete"
+	OperationRename OperationKind = "rename"
+)
+
+// Code returns the letter prefixing the log lines of operations of kind k.
+func (k OperationKind) Code() string {
+	switch k {
+	case OperationCreate:
+		return "C"
+	case OperationUpdate:
+		return "U"
+	case OperationRename:
+		return "R"
+	case OperationDelete:
+		return "D"
+	}
+	return "?"
+}
+
+// Operation is a single change to the labels of a repository. From is the
+// current name of the label for OperationRename, and Previous the current
+// label for OperationUpdate and OperationRename.
+type Operation struct {
+	Kind     OperationKind `json:"kind"`
+	Label    Label         `json:"label"`
+	From     string        `json:"from,omitempty"`
+	Previous *Label        `json:"previous,omitempty"`
+}
+
+// Changes returns the fields op changes as "field: old → new", or nil if
+// the previous label is unknown, e.g. in plans written by older versions.
+func (op Operation) Changes() []string {
+	if op.Previous == nil {
+		return nil
+	}
+	var changes []string
+	if op.Previous.Name != op.Label.Name {
+		changes = append(changes, fmt.Sprintf("name: %q → %q", op.Previous.Name, op.Label.Name))
+	}
+	if op.Previous.Color != op.Label.Color {
+		changes = append(changes, fmt.Sprintf("color: %s → %s", op.Previous.Color, op.Label.Color))
+	}
+	if op.Previous.Description != op.Label.Description {
+		changes = append(changes, fmt.Sprintf("description: %q → %q", op.Previous.Description, op.Label.Description))
+	}
+	return append(changes, extraChanges(*op.Previous, op.Label)...)
+}
+
+// label returns the label of op for log lines: the name only if the changes
+// are known and logged by explain, otherwise every field.
+func (op Operation) label() string {
+	if op.Previous == nil {
+		return op.Label.String()
+	}
+	return op.Label.Name
+}
+
+// explain returns the changes of op to append to its log line, if known.
+func (op Operation) explain() string {
+	changes := op.Changes()
+	if len(changes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(changes, ", ") + ")"
+}
+
+// Plan is the set of operations that syncs the labels of a repository with a
+// manifest. Current holds the labels of the repository the plan was made
+// against, so that applying it can be refused once they have changed.
+type Plan struct {
+	Owner      string      `json:"owner"`
+	Repo       string      `json:"repo"`
+	Current    []Label     `json:"current"`
+	Operations []Operation `json:"operations"`
+}
+
+// Syncer plans and applies label changes through a Provider.
+type Syncer struct {
+	provider         Provider
+	updateOnConflict bool
+	retries          int
+	concurrency      int
+	lockLabel        string
+	lockTimeout      time.Duration
+	fields           *Fields
+	reserved         []string
+	// operations are the kinds of operations planned, nil for all.
+	operations  map[OperationKind]bool
+	policy      Policy
+	rollback    bool
+	cache       *labelCache
+	callTimeout time.Duration
+	slowCall    time.Duration
+	// strictDescriptions fails on descriptions GitHub would alter.
+	strictDescriptions bool
+	chunkSize          int
+	verbose            bool
+
+	// pausedUntil holds every operation after a response has asked to wait
+	// with Retry-After.
+	pauseMu     sync.Mutex
+	pausedUntil time.Time
+}
+
+// SyncerOption configures a Syncer.
+type SyncerOption func(*Syncer)
+
+// WithUpdateOnConflict makes the Syncer update the existing label instead of
+// failing when a label to create already exists, e.g. created concurrently or
+// under a name differing only by case.
+func WithUpdateOnConflict() SyncerOption {
+	return func(s *Syncer) {
+		s.updateOnConflict = true
+	}
+}
+
+// WithVerbose makes the Syncer print every label it leaves alone rather than
+// only how many.
+func WithVerbose() SyncerOption {
+	return func(s *Syncer) {
+		s.verbose = true
+	}
+}
+
+// WithRetries makes the Syncer retry operations failing with transient errors
+// up to n times, with exponential backoff.
+func WithRetries(n int) SyncerOption {
+	return func(s *Syncer) {
+		s.retries = n
+	}
+}
+
+// WithConcurrency limits the operations applied at the same time to n. Zero
+// means no limit.
+func WithConcurrency(n int) SyncerOption {
+	return func(s *Syncer) {
+		s.concurrency = n
+	}
+}
+
+func NewSyncer(p Provider, opts ...SyncerOption) *Syncer {
+	s := &Syncer{
+		provider: p,
+		policy:   DefaultPolicy,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SyncLabels syncs the labels of owner/repo with labels. If prune is true,
+// labels not in labels are deleted.
+func (s *Syncer) SyncLabels(ctx context.Context, owner, repo string, labels []Label, prune bool) error {
+	plan, err := s.PlanLabels(ctx, owner, repo, labels, prune)
+	if err != nil {
+		return err
+	}
+	return s.ApplyPlan(ctx, plan)
+}
+
+// PlanLabels returns the plan to sync the labels of owner/repo with labels.
+// If prune is true, labels not in labels are planned to be deleted.
+// Description templates are executed for owner/repo. Only the fields
+// configured with WithFields are enforced on existing labels, and labels with
+// a reserved prefix are left alone. Descriptions aren't enforced if the
+// provider doesn't support them, and only the operations configured with
+// WithOperations are planned.
+func (s *Syncer) PlanLabels(ctx context.Context, owner, repo string, labels []Label, prune bool) (*Plan, error) {
+	plan, err := s.planLabels(ctx, owner, repo, labels, prune)
+	if err != nil {
+		return nil, err
+	}
+	fields := s.fields
+	if d, ok := s.provider.(DescriptionSupporter); ok && d.DescriptionsUnsupported() {
+		f := AllFields
+		if fields != nil {
+			f = *fields
+		}
+		f.Description = false
+		fields = &f
+	}
+	if fields != nil {
+		plan.enforce(*fields)
+	}
+	reserved := plan.dropReserved(s.reserved)
+	if s.verbose {
+		for _, op := range reserved {
+			Warnf("label: %s left alone on: %s/%s, reserved", op.Label.Name, owner, repo)
+		}
+	} else if len(reserved) > 0 {
+		Warnf("labels: %d left alone on: %s/%s, reserved", len(reserved), owner, repo)
+	}
+	skipped := plan.dropOperations(s.operations)
+	if s.verbose {
+		for _, op := range skipped {
+			Warnf("label: %s %s skipped on: %s/%s, not in operations", op.Label.Name, op.Kind, owner, repo)
+		}
+	} else if len(skipped) > 0 {
+		Warnf("labels: %d operation(s) skipped on: %s/%s, not in operations", len(skipped), owner, repo)
+	}
+	return plan, nil
+}
+
+// planLabels is PlanLabels enforcing every field.
+func (s *Syncer) planLabels(ctx context.Context, owner, repo string, labels []Label, prune bool) (*Plan, error) {
+	labels, err := s.renderLabels(ctx, owner, repo, labels)
+	if err != nil {
+		return nil, err
+	}
+	labels = supportedFields(s.provider, labels)
+	if labels, err = s.normalizeDescriptions(owner, repo, labels); err != nil {
+		return nil, err
+	}
+	currentLabels, err := s.listLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlanWithPolicy(owner, repo, currentLabels, labels, prune, s.policy), nil
+}
+
+// NewPlan returns the plan to sync currentLabels, the labels of owner/repo,
+// with labels.
+func NewPlan(owner, repo string, currentLabels, labels []Label, prune bool) *Plan {
+	return NewPlanWithPolicy(owner, repo, currentLabels, labels, prune, DefaultPolicy)
+}
+
+// NewPlanWithPolicy is NewPlan with the operations decided by policy.
+func NewPlanWithPolicy(owner, repo string, currentLabels, labels []Label, prune bool, policy Policy) *Plan {
+	labelMap := make(map[string]Label)
+	for _, l := range labels {
+		labelMap[l.Name] = l
+	}
+	currentLabelMap := make(map[string]Label)
+	for _, l := range currentLabels {
+		currentLabelMap[l.Name] = l
+	}
+
+	plan := &Plan{
+		Owner:   owner,
+		Repo:    repo,
+		Current: currentLabels,
+	}
+
+	// A label with a new name is renamed as long as the repository still has
+	// it under its old name only.
+	renames := make(map[string]string)
+	for _, l := range labels {
+		if _, ok := currentLabelMap[l.Name]; ok || len(l.from) == 0 {
+			continue
+		}
+		if _, ok := currentLabelMap[l.from]; ok {
+			renames[l.Name] = l.from
+			labelMap[l.from] = l
+		}
+	}
+	// A label the repository has under a name differing only by case is
+	// renamed too, rather than deleted and created again, which would strip
+	// it from its issues, or rejected as a conflict. Being an update, it's
+	// left alone for create-only labels.
+	byCase := make(map[string][]string)
+	for _, l := range currentLabels {
+		key := strings.ToLower(l.Name)
+		byCase[key] = append(byCase[key], l.Name)
+	}
+	caseOnly := make(map[string]bool)
+	for _, l := range labels {
+		if _, ok := currentLabelMap[l.Name]; ok {
+			continue
+		}
+		if _, ok := renames[l.Name]; ok {
+			continue
+		}
+		names := byCase[strings.ToLower(l.Name)]
+		if len(names) != 1 {
+			continue
+		}
+		if _, ok := labelMap[names[0]]; ok {
+			continue
+		}
+		renames[l.Name] = names[0]
+		labelMap[names[0]] = l
+		caseOnly[l.Name] = true
+	}
+
+	if prune {
+		for _, currentLabel := range currentLabels {
+			if _, ok := labelMap[currentLabel.Name]; ok || !policy.ShouldDelete(currentLabel) {
+				continue
+			}
+			plan.Operations = append(plan.Operations, Operation{Kind: OperationDelete, Label: currentLabel})
+		}
+	}
+
+	for _, l := range labels {
+		if from, ok := renames[l.Name]; ok {
+			if caseOnly[l.Name] && l.Policy == PolicyCreateOnly {
+				continue
+			}
+			previous := currentLabelMap[from]
+			plan.Operations = append(plan.Operations, Operation{Kind: OperationRename, Label: l, From: from, Previous: &previous})
+			continue
+		}
+		currentLabel, ok := currentLabelMap[l.Name]
+		if !ok {
+			if policy.ShouldCreate(l) {
+				plan.Operations = append(plan.Operations, Operation{Kind: OperationCreate, Label: l})
+			}
+			continue
+		}
+		if !sameLabel(currentLabel, l) && shouldUpdate(policy, currentLabel, l) {
+			currentLabel := currentLabel
+			plan.Operations = append(plan.Operations, Operation{Kind: OperationUpdate, Label: l, Previous: &currentLabel})
+		}
+	}
+	detectEmojiRenames(plan)
+
+	return plan
+}
+
+// Keep removes the deletions of the labels in names from p and returns the
+// labels no longer deleted.
+func (p *Plan) Keep(names map[string]bool) []Label {
+	var (
+		kept []Label
+		ops  []Operation
+	)
+	for _, op := range p.Operations {
+		if op.Kind == OperationDelete && names[op.Label.Name] {
+			kept = append(kept, op.Label)
+			continue
+		}
+		ops = append(ops, op)
+	}
+	p.Operations = ops
+	return kept
+}
+
+// VerifyPlan returns an error if the labels of the repository have changed
+// since plan was made.
+func (s *Syncer) VerifyPlan(ctx context.Context, plan *Plan) error {
+	currentLabels, err := s.listLabels(ctx, plan.Owner, plan.Repo)
+	if err != nil {
+		return err
+	}
+	if !equalLabels(currentLabels, plan.Current) {
+		return fmt.Errorf("labels on %s/%s have changed since the plan was made", plan.Owner, plan.Repo)
+	}
+	return nil
+}
+
+// ApplyPlan executes the operations of plan. Deletions run first so that
+// renamed labels don't conflict with the labels they replace. Then the other
+// operations start in plan order, i.e. manifest order, so that with limited
+// concurrency the first labels of the manifest exist first.
+func (s *Syncer) ApplyPlan(ctx context.Context, plan *Plan) error {
+	var deletes, others []Operation
+	for _, op := range plan.Operations {
+		if op.Kind == OperationDelete {
+			deletes = append(deletes, op)
+		} else {
+			others = append(others, op)
+		}
+	}
+	var j *journal
+	if s.rollback {
+		j = &journal{}
+	}
+	total := len(plan.Operations)
+	err := s.applyChunks(ctx, j, plan.Owner, plan.Repo, deletes, 0, total)
+	if err == nil {
+		err = s.applyChunks(ctx, j, plan.Owner, plan.Repo, others, len(deletes), total)
+	}
+	if err != nil && j != nil && len(j.ops) > 0 {
+		if e := s.rollbackPlan(ctx, plan, j); e != nil {
+			return multierr.Append(err, e)
+		}
+	}
+	return err
+}
+
+// applyAll executes ops concurrently, up to the configured concurrency. Once
+// an operation has failed, no more operations are started. The log of every
+// operation is printed once all have finished, ordered by label name, so that
+// it doesn't depend on the order the operations ran in.
+func (s *Syncer) applyAll(ctx context.Context, j *journal, owner, repo string, ops []Operation) error {
+	var (
+		eg     errgroup.Group
+		sem    chan struct{}
+		failed int32
+		logs   = make([]bytes.Buffer, len(ops))
+	)
+	if s.concurrency > 0 {
+		sem = make(chan struct{}, s.concurrency)
+	}
+	acquire := func() {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+	}
+	release := func() {
+		if sem != nil {
+			<-sem
+		}
+	}
+
+	for i, op := range ops {
+		acquire()
+		if atomic.LoadInt32(&failed) != 0 {
+			release()
+			break
+		}
+		i, op := i, op
+		eg.Go(func() error {
+			defer release()
+			if err := s.apply(ctx, &logs[i], j, owner, repo, op); err != nil {
+				atomic.StoreInt32(&failed, 1)
+				return err
+			}
+			return nil
+		})
+	}
+	err := eg.Wait()
+	var out bytes.Buffer
+	for _, i := range byName(ops) {
+		printLog(&out, ops[i].Kind, logs[i].String())
+	}
+	flushLog(&out)
+	return err
+}
+
+// byName returns the indices of ops ordered by label name and then kind.
+func byName(ops []Operation) []int {
+	idx := make([]int, len(ops))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := ops[idx[i]], ops[idx[j]]
+		if a.Label.Name != b.Label.Name {
+			return a.Label.Name < b.Label.Name
+		}
+		return a.Kind < b.Kind
+	})
+	return idx
+}
+
+// printLog writes every line of log to w prefixed with the code of kind.
+func printLog(w io.Writer, kind OperationKind, log string) {
+	for _, line := range strings.SplitAfter(log, "\n") {
+		if len(line) > 0 {
+			fmt.Fprintf(w, "%s %s", kind.Code(), line)
+		}
+	}
+}
+
+// stdoutMu serializes flushLog so that the logs of syncs running at the same
+// time, e.g. for webhooks served concurrently, don't interleave.
+var stdoutMu sync.Mutex
+
+// flushLog writes log to stdout at once.
+func flushLog(log *bytes.Buffer) {
+	stdoutMu.Lock()
+	defer stdoutMu.Unlock()
+	os.Stdout.Write(log.Bytes())
+}
+
+// apply executes op, retrying it on transient errors as configured. Every
+// operation is safe to run again: a label already created, renamed or deleted
+// by a previous attempt counts as done. Changes actually made are recorded in
+// j.
+//
+// Regardless of retries, a 403 response with Retry-After pauses the whole
+// sync for that long, once per operation, before the error is returned.
+func (s *Syncer) apply(ctx context.Context, w io.Writer, j *journal, owner, repo string, op Operation) error {
+	paused := false
+	for attempt := 0; ; attempt++ {
+		if err := s.waitPause(ctx); err != nil {
+			return err
+		}
+		what := fmt.Sprintf("label: %s %s on: %s/%s", op.Label.Name, op.Kind, owner, repo)
+		err := s.call(ctx, w, what, func(ctx context.Context) error {
+			return s.applyOnce(ctx, w, j, owner, repo, op)
+		})
+		if err == nil {
+			return nil
+		}
+		s.cache.invalidate(owner, repo)
+		if d := retryAfter(err); d > 0 && !paused {
+			paused = true
+			attempt--
+			s.pause(d)
+			fmt.Fprintf(w, "label: %s %s failed on: %s/%s, pausing the sync for %s as asked: %v\n", op.Label.Name, op.Kind, owner, repo, d, err)
+			continue
+		}
+		if attempt >= s.retries || !retryable(err) {
+			return err
+		}
+		wait := time.Duration(1<<uint(attempt)) * time.Second
+		fmt.Fprintf(w, "label: %s %s failed on: %s/%s, retrying in %s: %v\n", op.Label.Name, op.Kind, owner, repo, wait, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *Syncer) applyOnce(ctx context.Context, w io.Writer, j *journal, owner, repo string, op Operation) error {
+	switch op.Kind {
+	case OperationCreate:
+		err := s.provider.CreateLabel(ctx, owner, repo, op.Label)
+		if errors.Is(err, ErrAlreadyExists) {
+			return s.resolveExisting(ctx, w, j, owner, repo, op.Label, err)
+		}
+		if err != nil {
+			return err
+		}
+		s.record(j, owner, repo, op)
+		fmt.Fprintf(w, "label: %s created on: %s/%s\n", op.Label, owner, repo)
+	case OperationUpdate:
+		if err := s.provider.UpdateLabel(ctx, owner, repo, op.Label.Name, op.Label); err != nil {
+			return err
+		}
+		s.record(j, owner, repo, op)
+		fmt.Fprintf(w, "label: %s updated on: %s/%s%s\n", op.label(), owner, repo, op.explain())
+	case OperationRename:
+		err := s.renameLabel(ctx, owner, repo, op.From, op.Label)
+		if errors.Is(err, ErrNotFound) {
+			// The label may have been renamed by a previous attempt.
+			return s.resolveExisting(ctx, w, j, owner, repo, op.Label, err)
+		}
+		if err != nil {
+			return err
+		}
+		s.record(j, owner, repo, op)
+		fmt.Fprintf(w, "label: %s renamed to %s on: %s/%s%s\n", op.From, op.label(), owner, repo, op.explain())
+	case OperationDelete:
+		err := s.provider.DeleteLabel(ctx, owner, repo, op.Label.Name)
+		if errors.Is(err, ErrNotFound) {
+			fmt.Fprintf(w, "label: %s already deleted from: %s/%s\n", op.Label.Name, owner, repo)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.record(j, owner, repo, op)
+		fmt.Fprintf(w, "label: %s deleted from: %s/%s\n", op.Label.Name, owner, repo)
+	default:
+		return fmt.Errorf("unknown operation: %s", op.Kind)
+	}
+	return nil
+}
+
+// record records op, having been applied to owner/repo, in j and the label
+// cache.
+func (s *Syncer) record(j *journal, owner, repo string, op Operation) {
+	j.record(op)
+	s.cache.apply(owner, repo, op)
+}
+
+// resolveExisting handles label turning out to exist already while creating or
+// renaming it. A label exactly matching label counts as done. Otherwise the
+// existing label, possibly named differently by case, is updated if
+// configured, or err is returned.
+func (s *Syncer) resolveExisting(ctx context.Context, w io.Writer, j *journal, owner, repo string, label Label, err error) error {
+	s.cache.invalidate(owner, repo)
+	current, e := s.listLabels(ctx, owner, repo)
+	if e != nil {
+		return e
+	}
+	for _, l := range current {
+		if collisionKey(l.Name) != collisionKey(label.Name) {
+			continue
+		}
+		if l.Name == label.Name && sameLabel(l, label) {
+			fmt.Fprintf(w, "label: %s already exists on: %s/%s\n", label, owner, repo)
+			return nil
+		}
+		if !s.updateOnConflict {
+			return err
+		}
+		fmt.Fprintf(w, "label: %s already exists on: %s/%s as %s, updating it instead\n", label.Name, owner, repo, l.Name)
+		update := s.provider.UpdateLabel
+		if l.Name != label.Name {
+			update = s.renameLabel
+		}
+		if e := update(ctx, owner, repo, l.Name, label); e != nil {
+			return e
+		}
+		op := Operation{Kind: OperationUpdate, Label: label, Previous: &l}
+		s.record(j, owner, repo, op)
+		fmt.Fprintf(w, "label: %s updated on: %s/%s%s\n", op.label(), owner, repo, op.explain())
+		return nil
+	}
+	return err
+}
+
+// retryable reports whether err may not happen again, e.g. a server error, a
+// secondary rate limit, a network error or a timed out call.
+func retryable(err error) bool {
+	if errors.Is(err, ErrAlreadyExists) || errors.Is(err, ErrNotFound) {
+		return false
+	}
+	var (
+		abuse   *github.AbuseRateLimitError
+		resp    *github.ErrorResponse
+		httpErr *HTTPError
+		netErr  net.Error
+	)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	case errors.As(err, &abuse):
+		return true
+	case errors.As(err, &resp):
+		return resp.Response != nil && resp.Response.StatusCode >= 500
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusTooManyRequests
+	case errors.As(err, &netErr):
+		return true
+	}
+	return false
+}
+
+// String returns the operations of p ordered by label name, one per line
+// prefixed with the code of its kind, followed by a summary.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, i := range byName(p.Operations) {
+		op := p.Operations[i]
+		b.WriteString(op.Kind.Code() + " ")
+		if op.Kind == OperationRename {
+			fmt.Fprintf(&b, "label: %s will be renamed to %s on: %s/%s%s\n", op.From, op.label(), p.Owner, p.Repo, op.explain())
+			continue
+		}
+		fmt.Fprintf(&b, "label: %s will be %sd on: %s/%s%s\n", op.label(), op.Kind, p.Owner, p.Repo, op.explain())
+	}
+	fmt.Fprintf(&b, "plan: %d operation(s) on: %s/%s\n", len(p.Operations), p.Owner, p.Repo)
+	return b.String()
+}
+
+// ReadPlans reads plans written by WritePlans from path.
+func ReadPlans(path string) ([]*Plan, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var plans []*Plan
+	err = json.Unmarshal(buf, &plans)
+	return plans, err
+}
+
+// WritePlans writes plans to path as JSON.
+func WritePlans(path string, plans []*Plan) error {
+	buf, err := json.MarshalIndent(plans, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(buf, '\n'), 0644)
+}
+
+func equalLabels(a, b []Label) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	m := make(map[string]Label, len(a))
+	for _, l := range a {
+		m[l.Name] = l
+	}
+	for _, l := range b {
+		if cur, ok := m[l.Name]; !ok || !sameLabel(cur, l) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import "testing"
+
+// updateAll is a Policy updating every differing label.
+type updateAll struct{ Policy }
+
+func (updateAll) ShouldUpdate(current, desired Label) bool {
+	return true
+}
+
+func TestNewPlanWithPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []Label
+		labels  []Label
+		prune   bool
+		policy  Policy
+		want    []Operation
+	}{
+		{
+			name:    "missing label is created",
+			current: []Label{{Name: "bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+			want: []Operation{
+				{Kind: OperationCreate, Label: Label{Name: "docs", Color: "0075ca"}},
+			},
+		},
+		{
+			name:    "differing label is updated",
+			current: []Label{{Name: "bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			want: []Operation{
+				{Kind: OperationUpdate, Label: Label{Name: "bug", Color: "d73a4a"}, Previous: &Label{Name: "bug", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "unmanaged label is kept without prune",
+			current: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "extra", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+		},
+		{
+			name:    "unmanaged label is deleted with prune",
+			current: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "extra", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			prune:   true,
+			want: []Operation{
+				{Kind: OperationDelete, Label: Label{Name: "extra", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "new_name renames the old label",
+			current: []Label{{Name: "old", Color: "ffffff"}},
+			labels:  []Label{{Name: "new", Color: "d73a4a", from: "old"}},
+			prune:   true,
+			want: []Operation{
+				{Kind: OperationRename, Label: Label{Name: "new", Color: "d73a4a", from: "old"}, From: "old", Previous: &Label{Name: "old", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "new_name is ignored once renamed",
+			current: []Label{{Name: "old", Color: "ffffff"}, {Name: "new", Color: "d73a4a"}},
+			labels:  []Label{{Name: "new", Color: "d73a4a", from: "old"}},
+		},
+		{
+			name:    "name differing by case is renamed without prune",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			want: []Operation{
+				{Kind: OperationRename, Label: Label{Name: "bug", Color: "d73a4a"}, From: "Bug", Previous: &Label{Name: "Bug", Color: "d73a4a"}},
+			},
+		},
+		{
+			name:    "name differing by case is renamed with prune",
+			current: []Label{{Name: "Bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			prune:   true,
+			want: []Operation{
+				{Kind: OperationRename, Label: Label{Name: "bug", Color: "d73a4a"}, From: "Bug", Previous: &Label{Name: "Bug", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "name differing by case of a create-only label is left alone",
+			current: []Label{{Name: "Bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a", Policy: PolicyCreateOnly}},
+			prune:   true,
+		},
+		{
+			name:    "name differing by case of a managed label isn't renamed",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "Bug", Color: "d73a4a"}, {Name: "bug", Color: "d73a4a"}},
+			want: []Operation{
+				{Kind: OperationCreate, Label: Label{Name: "bug", Color: "d73a4a"}},
+			},
+		},
+		{
+			name:    "create-only label isn't updated",
+			current: []Label{{Name: "bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a", Policy: PolicyCreateOnly}},
+		},
+		{
+			name:    "create-only policy only creates",
+			current: []Label{{Name: "bug", Color: "ffffff"}, {Name: "extra", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+			prune:   true,
+			policy:  CreateOnlyPolicy,
+			want: []Operation{
+				{Kind: OperationCreate, Label: Label{Name: "docs", Color: "0075ca"}},
+			},
+		},
+		{
+			name:    "create-only label isn't updated by a custom policy",
+			current: []Label{{Name: "bug", Color: "ffffff"}, {Name: "docs", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a", Policy: PolicyCreateOnly}, {Name: "docs", Color: "0075ca"}},
+			policy:  updateAll{DefaultPolicy},
+			want: []Operation{
+				{Kind: OperationUpdate, Label: Label{Name: "docs", Color: "0075ca"}, Previous: &Label{Name: "docs", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "enforce label is updated regardless of the policy",
+			current: []Label{{Name: "bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a", Policy: PolicyEnforce}},
+			policy:  CreateOnlyPolicy,
+			want: []Operation{
+				{Kind: OperationUpdate, Label: Label{Name: "bug", Color: "d73a4a", Policy: PolicyEnforce}, Previous: &Label{Name: "bug", Color: "ffffff"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			if policy == nil {
+				policy = DefaultPolicy
+			}
+			plan := NewPlanWithPolicy("owner", "repo", tt.current, tt.labels, tt.prune, policy)
+			assertOperations(t, plan.Operations, tt.want)
+		})
+	}
+}
+
+func TestPlanDestructive(t *testing.T) {
+	tests := []struct {
+		kind OperationKind
+		want bool
+	}{
+		{kind: OperationCreate, want: false},
+		{kind: OperationUpdate, want: true},
+		{kind: OperationRename, want: true},
+		{kind: OperationDelete, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			plan := &Plan{Operations: []Operation{{Kind: tt.kind, Label: Label{Name: "bug"}}}}
+			if got := plan.Destructive(); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanString(t *testing.T) {
+	priority := 1
+	plan := NewPlan("owner", "repo",
+		[]Label{{Name: "old", Color: "ffffff"}, {Name: "bug", Color: "ffffff"}, {Name: "extra", Color: "ffffff"}},
+		[]Label{{Name: "new", Color: "ffffff", from: "old"}, {Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca", Priority: &priority}},
+		true)
+	want := `U label: bug will be updated on: owner/repo (color: ffffff → d73a4a)
+C label: {Name:docs Description: Color:0075ca Priority:1} will be created on: owner/repo
+D label: {Name:extra Description: Color:ffffff} will be deleted on: owner/repo
+R label: old will be renamed to new on: owner/repo (name: "old" → "new")
+plan: 4 operation(s) on: owner/repo
+`
+	if got := plan.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+// Policy decides which operations a plan makes, so that library users can
+// encode their own rules. Renames aren't subject to it.
+type Policy interface {
+	// ShouldCreate reports whether desired, missing from the repository, is
+	// created.
+	ShouldCreate(desired Label) bool
+	// ShouldUpdate reports whether current is updated to desired. It's only
+	// called when their color or description differ and desired has no
+	// policy of its own: create-only labels are never updated and enforce
+	// ones always are.
+	ShouldUpdate(current, desired Label) bool
+	// ShouldDelete reports whether current, missing from the manifest, is
+	// deleted. It's only called when pruning.
+	ShouldDelete(current Label) bool
+}
+
+var (
+	// DefaultPolicy creates missing labels, updates differing ones and
+	// deletes the others.
+	DefaultPolicy Policy = defaultPolicy{}
+	// CreateOnlyPolicy only creates missing labels, as if every label without
+	// a policy was create-only and pruning was disabled.
+	CreateOnlyPolicy Policy = createOnlyPolicy{}
+)
+
+type defaultPolicy struct{}
+
+func (defaultPolicy) ShouldCreate(desired Label) bool {
+	return true
+}
+
+func (defaultPolicy) ShouldUpdate(current, desired Label) bool {
+	return true
+}
+
+func (defaultPolicy) ShouldDelete(current Label) bool {
+	return true
+}
+
+type createOnlyPolicy struct{}
+
+func (createOnlyPolicy) ShouldCreate(desired Label) bool {
+	return true
+}
+
+func (createOnlyPolicy) ShouldUpdate(current, desired Label) bool {
+	return false
+}
+
+func (createOnlyPolicy) ShouldDelete(current Label) bool {
+	return false
+}
+
+// shouldUpdate reports whether current is updated to desired, by the policy of
+// desired if it has one and by policy otherwise.
+func shouldUpdate(policy Policy, current, desired Label) bool {
+	switch desired.Policy {
+	case PolicyCreateOnly:
+		return false
+	case PolicyEnforce:
+		return true
+	}
+	return policy.ShouldUpdate(current, desired)
+}
+
+// WithPolicy makes the Syncer plan with p instead of DefaultPolicy.
+func WithPolicy(p Policy) SyncerOption {
+	return func(s *Syncer) {
+		s.policy = p
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ProjectOption is an option of a single-select field of a project.
+type ProjectOption struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// ProjectField is a single-select field of a project.
+type ProjectField struct {
+	ID      string
+	Options []ProjectOption
+}
+
+// projectColors are the colors of project options with the label colors
+// closest to them.
+var projectColors = []struct {
+	name, hex string
+}{
+	{"GRAY", "8c959f"},
+	{"BLUE", "54aeff"},
+	{"GREEN", "4ac26b"},
+	{"YELLOW", "d4a72c"},
+	{"ORANGE", "fb8f44"},
+	{"RED", "ff8182"},
+	{"PINK", "ff80c8"},
+	{"PURPLE", "c297ff"},
+}
+
+const projectFieldQuery = `query($owner: String!, $number: Int!, $field: String!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        field(name: $field) {
+          ... on ProjectV2SingleSelectField {
+            id
+            options { id name color description }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const updateProjectFieldMutation = `mutation($field: ID!, $options: [ProjectV2SingleSelectFieldOptionInput!]) {
+  updateProjectV2Field(input: {fieldId: $field, singleSelectOptions: $options}) {
+    clientMutationId
+  }
+}`
+
+// GetProjectField returns the single-select field named field of the project
+// number owned by the user or organization owner.
+func (c *Client) GetProjectField(ctx context.Context, owner string, number int, field string) (*ProjectField, error) {
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				Field *struct {
+					ID      string          `json:"id"`
+					Options []ProjectOption `json:"options"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	vars := map[string]interface{}{
+		"owner":  owner,
+		"number": number,
+		"field":  field,
+	}
+	if err := c.graphql(ctx, projectFieldQuery, vars, &data); err != nil {
+		return nil, err
+	}
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("project %s/%d not found", owner, number)
+	}
+	f := data.RepositoryOwner.ProjectV2.Field
+	if f == nil || len(f.ID) == 0 {
+		return nil, fmt.Errorf("single-select field %q not found on project %s/%d", field, owner, number)
+	}
+	return &ProjectField{ID: f.ID, Options: f.Options}, nil
+}
+
+// ProjectOptions returns the options of a single-select field matching the
+// labels of group, in manifest order. Options already on field keep their IDs
+// so that the values set on items are preserved.
+func ProjectOptions(field *ProjectField, labels []Label, group string) []ProjectOption {
+	ids := make(map[string]string, len(field.Options))
+	for _, o := range field.Options {
+		ids[o.Name] = o.ID
+	}
+	var options []ProjectOption
+	for _, l := range labels {
+		if l.Group != group {
+			continue
+		}
+		options = append(options, ProjectOption{
+			ID:          ids[l.Name],
+			Name:        l.Name,
+			Color:       projectColor(l.Color),
+			Description: l.Description,
+		})
+	}
+	return options
+}
+
+// EqualProjectOptions reports whether a and b have the same options in the
+// same order.
+func EqualProjectOptions(a, b []ProjectOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Color != b[i].Color || a[i].Description != b[i].Description {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateProjectField replaces the options of the single-select field with
+// options.
+func (c *Client) UpdateProjectField(ctx context.Context, field *ProjectField, options []ProjectOption) error {
+	vars := map[string]interface{}{
+		"field":   field.ID,
+		"options": options,
+	}
+	return c.graphql(ctx, updateProjectFieldMutation, vars, nil)
+}
+
+// projectColor returns the project option color closest to the label color.
+func projectColor(color string) string {
+	r, g, b := rgb(color)
+	best, min := projectColors[0].name, -1
+	for _, pc := range projectColors {
+		pr, pg, pb := rgb(pc.hex)
+		d := (r-pr)*(r-pr) + (g-pg)*(g-pg) + (b-pb)*(b-pb)
+		if min < 0 || d < min {
+			best, min = pc.name, d
+		}
+	}
+	return best
+}
+
+func rgb(color string) (int, int, int) {
+	n, err := strconv.ParseUint(NormalizeColor(color), 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff)
+}
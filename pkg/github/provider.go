@@ -0,0 +1,101 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrAlreadyExists is returned, possibly wrapped, by Provider.CreateLabel
+	// when the label already exists.
+	ErrAlreadyExists = errors.New("label already exists")
+	// ErrNotFound is returned, possibly wrapped, by Provider.UpdateLabel and
+	// Provider.DeleteLabel when the label doesn't exist.
+	ErrNotFound = errors.New("label not found")
+)
+
+// HTTPError is returned by the providers calling REST APIs directly for
+// responses with an error status. It matches ErrAlreadyExists and ErrNotFound
+// with errors.Is for the corresponding statuses.
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Body       string
+	Header     http.Header
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, e.Status, e.Body)
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrAlreadyExists:
+		return e.StatusCode == http.StatusConflict
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// Provider is the backend holding the labels of repositories. Client is the
+// GitHub implementation, and pkg/gitlab and pkg/gitea implement it for GitLab
+// and Gitea; owner/repo identify a repository in the terms of the provider,
+// e.g. a group and a project on GitLab.
+//
+// The Syncer only reaches the labels through Provider: planning, applying,
+// retrying and rolling back are the same for every provider. Renames are
+// updates to a label of another name, unless the provider implements Renamer.
+// Providers may implement RepositoryGetter for description templates,
+// FieldSupporter for provider-specific fields and DescriptionSupporter if
+// descriptions may turn out to be unsupported.
+type Provider interface {
+	ListLabels(ctx context.Context, owner, repo string) ([]Label, error)
+	// CreateLabel returns ErrAlreadyExists if the label already exists.
+	CreateLabel(ctx context.Context, owner, repo string, label Label) error
+	// UpdateLabel updates the label name to label, renaming it if the names
+	// differ. It returns ErrNotFound if the label doesn't exist.
+	UpdateLabel(ctx context.Context, owner, repo, name string, label Label) error
+	// DeleteLabel returns ErrNotFound if the label doesn't exist.
+	DeleteLabel(ctx context.Context, owner, repo, name string) error
+}
+
+// Renamer is implemented by providers renaming labels otherwise than by
+// UpdateLabel, e.g. with a dedicated API call.
+type Renamer interface {
+	// RenameLabel renames the label from to label.Name and updates it to
+	// label. It returns ErrNotFound if the label doesn't exist.
+	RenameLabel(ctx context.Context, owner, repo, from string, label Label) error
+}
+
+// renameLabel renames the label from on owner/repo to label.Name with the
+// Renamer of s if its provider is one, and with UpdateLabel otherwise.
+func (s *Syncer) renameLabel(ctx context.Context, owner, repo, from string, label Label) error {
+	if r, ok := s.provider.(Renamer); ok {
+		return r.RenameLabel(ctx, owner, repo, from, label)
+	}
+	return s.provider.UpdateLabel(ctx, owner, repo, from, label)
+}
+
+var (
+	_ Provider         = (*Client)(nil)
+	_ RepositoryGetter = (*Client)(nil)
+)
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// memory is a Provider holding the labels of a single repository. Labels
+// named in fail can't be created.
+type memory struct {
+	mu     sync.Mutex
+	labels map[string]Label
+	fail   map[string]bool
+}
+
+func newMemory(labels ...Label) *memory {
+	m := &memory{labels: make(map[string]Label)}
+	for _, l := range labels {
+		m.labels[l.Name] = l
+	}
+	return m
+}
+
+func (m *memory) ListLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	labels := make([]Label, 0, len(m.labels))
+	for _, l := range m.labels {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels, nil
+}
+
+func (m *memory) CreateLabel(ctx context.Context, owner, repo string, label Label) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail[label.Name] {
+		return errors.New("unable to create " + label.Name)
+	}
+	if _, ok := m.labels[label.Name]; ok {
+		return ErrAlreadyExists
+	}
+	m.labels[label.Name] = label
+	return nil
+}
+
+func (m *memory) UpdateLabel(ctx context.Context, owner, repo, name string, label Label) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.labels[name]; !ok {
+		return ErrNotFound
+	}
+	delete(m.labels, name)
+	m.labels[label.Name] = label
+	return nil
+}
+
+func (m *memory) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.labels[name]; !ok {
+		return ErrNotFound
+	}
+	delete(m.labels, name)
+	return nil
+}
+
+// renamer is a memory renaming labels with RenameLabel only.
+type renamer struct {
+	*memory
+}
+
+func (r renamer) UpdateLabel(ctx context.Context, owner, repo, name string, label Label) error {
+	if name != label.Name {
+		panic("UpdateLabel called to rename " + name)
+	}
+	return r.memory.UpdateLabel(ctx, owner, repo, name, label)
+}
+
+func (r renamer) RenameLabel(ctx context.Context, owner, repo, from string, label Label) error {
+	return r.memory.UpdateLabel(ctx, owner, repo, from, label)
+}
+
+// assertLabels fails t unless the labels of p are want, in name order.
+func assertLabels(t *testing.T, p Provider, want []Label) {
+	t.Helper()
+	got, err := p.ListLabels(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got labels %v, want %v", got, want)
+	}
+}
+
+func TestSyncLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []Label
+		labels  []Label
+		prune   bool
+		want    []Label
+	}{
+		{
+			name:    "creates, updates and deletes",
+			current: []Label{{Name: "bug", Color: "ffffff"}, {Name: "extra", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+			prune:   true,
+			want:    []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+		},
+		{
+			name:    "renames with new_name",
+			current: []Label{{Name: "old", Color: "ffffff"}},
+			labels:  []Label{{Name: "new", Color: "d73a4a", from: "old"}},
+			prune:   true,
+			want:    []Label{{Name: "new", Color: "d73a4a", from: "old"}},
+		},
+		{
+			name:    "renames names differing by case",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			want:    []Label{{Name: "bug", Color: "d73a4a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMemory(tt.current...)
+			if err := NewSyncer(m).SyncLabels(context.Background(), "owner", "repo", tt.labels, tt.prune); err != nil {
+				t.Fatal(err)
+			}
+			assertLabels(t, m, tt.want)
+		})
+		t.Run(tt.name+" with a renamer", func(t *testing.T) {
+			m := newMemory(tt.current...)
+			if err := NewSyncer(renamer{m}).SyncLabels(context.Background(), "owner", "repo", tt.labels, tt.prune); err != nil {
+				t.Fatal(err)
+			}
+			assertLabels(t, m, tt.want)
+		})
+	}
+}
+
+func TestApplyPlan(t *testing.T) {
+	current := []Label{{Name: "bug", Color: "ffffff"}, {Name: "old", Color: "000000"}}
+	labels := []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}, {Name: "fail", Color: "000000"}, {Name: "later", Color: "000000"}}
+	tests := []struct {
+		name string
+		opts []SyncerOption
+		want []Label
+	}{
+		{
+			name: "stops at the failing chunk",
+			opts: []SyncerOption{WithChunkSize(1)},
+			want: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+		},
+		{
+			name: "rolls back the applied operations",
+			opts: []SyncerOption{WithChunkSize(1), WithRollback()},
+			want: current,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMemory(current...)
+			m.fail = map[string]bool{"fail": true}
+			syncer := NewSyncer(m, tt.opts...)
+			plan, err := syncer.PlanLabels(context.Background(), "owner", "repo", labels, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := syncer.ApplyPlan(context.Background(), plan); err == nil {
+				t.Fatal("got no error, want the failed creation")
+			}
+			assertLabels(t, m, tt.want)
+		})
+	}
+}
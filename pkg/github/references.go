@@ -0,0 +1,112 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ReferencedLabels returns the names of the labels referenced by the issue
+// templates (ISSUE_TEMPLATE/*.md and issue forms) and by labeler.yml of
+// actions/labeler in dir, typically .github, mapped to the files referencing
+// them. Missing files are ignored.
+func ReferencedLabels(dir string) (map[string][]string, error) {
+	refs := make(map[string][]string)
+	add := func(name, path string) {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			refs[name] = append(refs[name], path)
+		}
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "ISSUE_TEMPLATE", "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		ext := filepath.Ext(path)
+		if filepath.Base(path) == "config.yml" || (ext != ".md" && ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if ext == ".md" {
+			buf = frontMatter(buf)
+		}
+		var tmpl struct {
+			Labels interface{} `yaml:"labels"`
+		}
+		if err := yaml.Unmarshal(buf, &tmpl); err != nil {
+			return nil, err
+		}
+		switch v := tmpl.Labels.(type) {
+		case string:
+			// Markdown templates list labels separated by commas.
+			for _, name := range strings.Split(v, ",") {
+				add(name, path)
+			}
+		case []interface{}:
+			for _, name := range v {
+				if s, ok := name.(string); ok {
+					add(s, path)
+				}
+			}
+		}
+	}
+
+	path := filepath.Join(dir, "labeler.yml")
+	buf, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var labeler yaml.MapSlice
+		if err := yaml.Unmarshal(buf, &labeler); err != nil {
+			return nil, err
+		}
+		for _, item := range labeler {
+			if s, ok := item.Key.(string); ok {
+				add(s, path)
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// frontMatter returns the YAML front matter of a Markdown document.
+func frontMatter(buf []byte) []byte {
+	const sep = "---"
+	lines := bytes.Split(buf, []byte("\n"))
+	if len(lines) == 0 || string(bytes.TrimSpace(lines[0])) != sep {
+		return nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if string(bytes.TrimSpace(lines[i])) == sep {
+			return bytes.Join(lines[1:i], []byte("\n"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// LabelUsage is how much a label of a repository is used by its issues, pull
+// requests and discussions.
+type LabelUsage struct {
+	Repository  string `json:"repository"`
+	Name        string `json:"name"`
+	Open        int    `json:"open"`
+	Closed      int    `json:"closed"`
+	Discussions int    `json:"discussions"`
+	// LastUsed is the last time an issue, pull request or discussion with
+	// the label was updated, or nil if the label is unused.
+	LastUsed *time.Time `json:"last_used"`
+}
+
+// Used reports whether anything has the label.
+func (u *LabelUsage) Used() bool {
+	return u.Open+u.Closed+u.Discussions > 0
+}
+
+func (u *LabelUsage) use(t time.Time) {
+	if u.LastUsed == nil || t.After(*u.LastUsed) {
+		u.LastUsed = &t
+	}
+}
+
+const discussionLabelsQuery = `query($owner: String!, $repo: String!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    discussions(first: 100, after: $after) {
+      nodes {
+        updatedAt
+        labels(first: 100) { nodes { name } }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const labelCreationQuery = `query($owner: String!, $repo: String!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    labels(first: 100, after: $after) {
+      nodes { name createdAt }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// LabelUsages returns the usage of every label of owner/repo, in the order the
+// labels are listed. Issues are listed once for the whole repository rather
+// than once per label to save API calls.
+func (c *Client) LabelUsages(ctx context.Context, owner, repo string) ([]LabelUsage, error) {
+	labels, err := c.ListLabels(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	usages := make([]LabelUsage, 0, len(labels))
+	index := make(map[string]int, len(labels))
+	for i, l := range labels {
+		usages = append(usages, LabelUsage{
+			Repository: owner + "/" + repo,
+			Name:       l.Name,
+		})
+		index[l.Name] = i
+	}
+
+	opt := &github.IssueListByRepoOptions{
+		State: "all",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	for {
+		issues, resp, err := c.githubClient.Issues.ListByRepo(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			for _, l := range issue.Labels {
+				i, ok := index[l.GetName()]
+				if !ok {
+					continue
+				}
+				u := &usages[i]
+				if issue.GetState() == "open" {
+					u.Open++
+				} else {
+					u.Closed++
+				}
+				u.use(issue.GetUpdatedAt())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	// Discussions are only available through the GraphQL API.
+	vars := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
+	for {
+		var data struct {
+			Repository struct {
+				Discussions struct {
+					Nodes []struct {
+						UpdatedAt time.Time `json:"updatedAt"`
+						Labels    struct {
+							Nodes []struct {
+								Name string `json:"name"`
+							} `json:"nodes"`
+						} `json:"labels"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"discussions"`
+			} `json:"repository"`
+		}
+		if err := c.graphql(ctx, discussionLabelsQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		ds := data.Repository.Discussions
+		for _, d := range ds.Nodes {
+			for _, l := range d.Labels.Nodes {
+				i, ok := index[l.Name]
+				if !ok {
+					continue
+				}
+				usages[i].Discussions++
+				usages[i].use(d.UpdatedAt)
+			}
+		}
+		if !ds.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = ds.PageInfo.EndCursor
+	}
+	return usages, nil
+}
+
+// LabelsInUse returns the names of the labels of owner/repo used by an issue,
+// a pull request or a discussion.
+func (c *Client) LabelsInUse(ctx context.Context, owner, repo string) (map[string]bool, error) {
+	usages, err := c.LabelUsages(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, u := range usages {
+		if u.Used() {
+			names[u.Name] = true
+		}
+	}
+	return names, nil
+}
+
+// LabelsCreatedSince returns the names of the labels of owner/repo created
+// after t. Creation times are only available through the GraphQL API.
+func (c *Client) LabelsCreatedSince(ctx context.Context, owner, repo string, t time.Time) (map[string]bool, error) {
+	vars := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
+	names := make(map[string]bool)
+	for {
+		var data struct {
+			Repository struct {
+				Labels struct {
+					Nodes []struct {
+						Name      string    `json:"name"`
+						CreatedAt time.Time `json:"createdAt"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+		if err := c.graphql(ctx, labelCreationQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		ls := data.Repository.Labels
+		for _, l := range ls.Nodes {
+			if l.CreatedAt.After(t) {
+				names[l.Name] = true
+			}
+		}
+		if !ls.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = ls.PageInfo.EndCursor
+	}
+	return names, nil
+}
+
+// StaleLabels returns the usages of the labels in names that nothing updated
+// since t has, oldest first with unused labels first of all. They are the
+// candidates for the next prune.
+func StaleLabels(usages []LabelUsage, names map[string]bool, t time.Time) []LabelUsage {
+	var stale []LabelUsage
+	for _, u := range usages {
+		if names[u.Name] && (u.LastUsed == nil || u.LastUsed.Before(t)) {
+			stale = append(stale, u)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		a, b := stale[i].LastUsed, stale[j].LastUsed
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+	return stale
+}
+
+// WriteReport writes usages to w in format "csv" or "json".
+func WriteReport(w io.Writer, format string, usages []LabelUsage) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"repository", "name", "open", "closed", "discussions", "last_used"})
+		for _, u := range usages {
+			var lastUsed string
+			if u.LastUsed != nil {
+				lastUsed = u.LastUsed.UTC().Format(time.RFC3339)
+			}
+			cw.Write([]string{u.Repository, u.Name, strconv.Itoa(u.Open), strconv.Itoa(u.Closed), strconv.Itoa(u.Discussions), lastUsed})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "json":
+		if usages == nil {
+			usages = []LabelUsage{}
+		}
+		buf, err := json.MarshalIndent(usages, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(buf, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+}
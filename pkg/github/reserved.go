@@ -0,0 +1,102 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// WithReservedPrefixes makes the Syncer never touch labels matching one of
+// prefixes as ReservedLabel tells, e.g. labels managed by bots.
+func WithReservedPrefixes(prefixes []string) SyncerOption {
+	return func(s *Syncer) {
+		s.reserved = prefixes
+	}
+}
+
+// Reserved reports whether name starts with one of prefixes, regardless of
+// case. Prefixes matching other fields are ignored.
+func Reserved(name string, prefixes []string) bool {
+	return ReservedLabel(Label{Name: name}, prefixes)
+}
+
+// ReservedLabel reports whether l matches one of prefixes, regardless of case.
+// A prefix "description:<text>" matches labels whose description contains
+// text, e.g. labels marked by bots, and "color:<color>" labels of that color.
+// Any other prefix, optionally written "name:<prefix>", matches labels whose
+// name starts with it.
+func ReservedLabel(l Label, prefixes []string) bool {
+	for _, p := range prefixes {
+		field, value := "name", p
+		if i := strings.Index(p, ":"); i >= 0 {
+			switch f := strings.ToLower(p[:i]); f {
+			case "name", "description", "color":
+				field, value = f, p[i+1:]
+			}
+		}
+		if len(value) == 0 {
+			continue
+		}
+		value = strings.ToLower(value)
+		switch field {
+		case "name":
+			if strings.HasPrefix(strings.ToLower(l.Name), value) {
+				return true
+			}
+		case "description":
+			if strings.Contains(strings.ToLower(l.Description), value) {
+				return true
+			}
+		case "color":
+			if len(l.Color) > 0 && NormalizeColor(l.Color) == NormalizeColor(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateReserved returns an error for every label of labels matching one of
+// the reserved prefixes, combined into a single error.
+func ValidateReserved(labels []Label, prefixes []string) error {
+	var err error
+	for _, l := range labels {
+		if ReservedLabel(l, prefixes) {
+			err = multierr.Append(err, fmt.Errorf("label %q: matches a reserved prefix", l.Name))
+		}
+	}
+	return err
+}
+
+// dropReserved removes from p the operations on labels matching one of the
+// reserved prefixes, either as they are or as they'd be, and returns them.
+func (p *Plan) dropReserved(prefixes []string) []Operation {
+	var dropped []Operation
+	ops := p.Operations[:0]
+	for _, op := range p.Operations {
+		if ReservedLabel(op.Label, prefixes) ||
+			(op.Previous != nil && ReservedLabel(*op.Previous, prefixes)) ||
+			(op.Kind == OperationRename && Reserved(op.From, prefixes)) {
+			dropped = append(dropped, op)
+			continue
+		}
+		ops = append(ops, op)
+	}
+	p.Operations = ops
+	return dropped
+}
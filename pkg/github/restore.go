@@ -0,0 +1,75 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import "context"
+
+// PlanRestore returns the plan to bring the labels of owner/repo back to
+// labels, typically read from a backup. Unlike PlanLabels, a label that would
+// be deleted is renamed instead when it has the same description and color as
+// exactly one label to be created, which keeps it on its issues. Every field
+// is restored regardless of WithFields.
+func (s *Syncer) PlanRestore(ctx context.Context, owner, repo string, labels []Label, prune bool) (*Plan, error) {
+	plan, err := s.planLabels(ctx, owner, repo, labels, prune)
+	if err != nil {
+		return nil, err
+	}
+	detectRenames(plan)
+	return plan, nil
+}
+
+func detectRenames(plan *Plan) {
+	key := func(l Label) string {
+		return l.Description + "\x00" + NormalizeColor(l.Color)
+	}
+
+	deletes := make(map[string][]int)
+	creates := make(map[string][]int)
+	for i, op := range plan.Operations {
+		switch op.Kind {
+		case OperationDelete:
+			deletes[key(op.Label)] = append(deletes[key(op.Label)], i)
+		case OperationCreate:
+			creates[key(op.Label)] = append(creates[key(op.Label)], i)
+		}
+	}
+
+	drop := make(map[int]bool)
+	for k, ds := range deletes {
+		cs := creates[k]
+		if len(ds) != 1 || len(cs) != 1 {
+			continue
+		}
+		d, c := ds[0], cs[0]
+		// The deletion is dropped below by compacting plan.Operations in
+		// place, which would overwrite a pointer into it.
+		previous := plan.Operations[d].Label
+		plan.Operations[c] = Operation{
+			Kind:     OperationRename,
+			Previous: &previous,
+			Label:    plan.Operations[c].Label,
+			From:     plan.Operations[d].Label.Name,
+		}
+		drop[d] = true
+	}
+
+	ops := plan.Operations[:0]
+	for i, op := range plan.Operations {
+		if !drop[i] {
+			ops = append(ops, op)
+		}
+	}
+	plan.Operations = ops
+}
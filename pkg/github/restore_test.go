@@ -0,0 +1,106 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDetectRenames(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []Label
+		labels  []Label
+		want    []Operation
+	}{
+		{
+			name:    "same description and color is renamed",
+			current: []Label{{Name: "old", Description: "d", Color: "ffffff"}, {Name: "kept", Color: "000000"}},
+			labels:  []Label{{Name: "new", Description: "d", Color: "ffffff"}, {Name: "kept", Color: "000000"}},
+			want: []Operation{
+				{Kind: OperationRename, Label: Label{Name: "new", Description: "d", Color: "ffffff"}, From: "old", Previous: &Label{Name: "old", Description: "d", Color: "ffffff"}},
+			},
+		},
+		{
+			name:    "different color is deleted and created",
+			current: []Label{{Name: "old", Color: "ffffff"}},
+			labels:  []Label{{Name: "new", Color: "000000"}},
+			want: []Operation{
+				{Kind: OperationDelete, Label: Label{Name: "old", Color: "ffffff"}},
+				{Kind: OperationCreate, Label: Label{Name: "new", Color: "000000"}},
+			},
+		},
+		{
+			name:    "ambiguous match is deleted and created",
+			current: []Label{{Name: "a", Color: "ffffff"}, {Name: "b", Color: "ffffff"}},
+			labels:  []Label{{Name: "c", Color: "ffffff"}},
+			want: []Operation{
+				{Kind: OperationDelete, Label: Label{Name: "a", Color: "ffffff"}},
+				{Kind: OperationDelete, Label: Label{Name: "b", Color: "ffffff"}},
+				{Kind: OperationCreate, Label: Label{Name: "c", Color: "ffffff"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := NewPlan("owner", "repo", tt.current, tt.labels, true)
+			detectRenames(plan)
+			assertOperations(t, plan.Operations, tt.want)
+		})
+	}
+}
+
+func TestPlanRestore(t *testing.T) {
+	m := newMemory(Label{Name: "renamed", Description: "d", Color: "ffffff"}, Label{Name: "bug", Color: "000000"})
+	backup := []Label{{Name: "original", Description: "d", Color: "ffffff"}, {Name: "bug", Color: "d73a4a"}}
+	// Restores enforce every field, whatever the Syncer enforces.
+	syncer := NewSyncer(m, WithFields(Fields{}))
+	plan, err := syncer.PlanRestore(context.Background(), "owner", "repo", backup, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertOperations(t, plan.Operations, []Operation{
+		{Kind: OperationRename, Label: Label{Name: "original", Description: "d", Color: "ffffff"}, From: "renamed", Previous: &Label{Name: "renamed", Description: "d", Color: "ffffff"}},
+		{Kind: OperationUpdate, Label: Label{Name: "bug", Color: "d73a4a"}, Previous: &Label{Name: "bug", Color: "000000"}},
+	})
+
+	if err := syncer.ApplyPlan(context.Background(), plan); err != nil {
+		t.Fatal(err)
+	}
+	assertLabels(t, m, []Label{{Name: "bug", Color: "d73a4a"}, {Name: "original", Description: "d", Color: "ffffff"}})
+}
+
+// assertOperations fails t unless got are the operations of want, comparing
+// the labels Previous points to rather than the pointers.
+func assertOperations(t *testing.T, got, want []Operation) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d operation(s) %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if (g.Previous == nil) != (w.Previous == nil) || (g.Previous != nil && !reflect.DeepEqual(*g.Previous, *w.Previous)) {
+			t.Errorf("operation %d: got previous %v, want %v", i, g.Previous, w.Previous)
+		}
+		g.Previous, w.Previous = nil, nil
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("operation %d: got %+v, want %+v", i, g, w)
+		}
+	}
+}
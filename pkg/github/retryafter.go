@@ -0,0 +1,88 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// retryAfter returns how long the 403 response behind err asks to wait with
+// Retry-After, or 0 if it doesn't.
+func retryAfter(err error) time.Duration {
+	var (
+		abuse   *github.AbuseRateLimitError
+		resp    *github.ErrorResponse
+		httpErr *HTTPError
+		status  int
+		header  http.Header
+	)
+	switch {
+	case errors.As(err, &abuse):
+		if abuse.RetryAfter != nil && abuse.Response != nil && abuse.Response.StatusCode == http.StatusForbidden {
+			return *abuse.RetryAfter
+		}
+		return 0
+	case errors.As(err, &resp):
+		if resp.Response != nil {
+			status, header = resp.Response.StatusCode, resp.Response.Header
+		}
+	case errors.As(err, &httpErr):
+		status, header = httpErr.StatusCode, httpErr.Header
+	}
+	if status != http.StatusForbidden {
+		return 0
+	}
+	v := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// pause holds every operation of s until d has passed.
+func (s *Syncer) pause(d time.Duration) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	if until := time.Now().Add(d); until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+}
+
+// waitPause waits for the pause of s, if any, to be over.
+func (s *Syncer) waitPause(ctx context.Context) error {
+	s.pauseMu.Lock()
+	d := time.Until(s.pausedUntil)
+	s.pauseMu.Unlock()
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// ProposeManifest opens a pull request against the default branch of
+// owner/repo that replaces the file at path with content. The pull request is
+// made from branch, which is reset to the default branch first. It returns the
+// URL of the pull request, or an empty string if the file already has content.
+func (c *Client) ProposeManifest(ctx context.Context, owner, repo, path, branch, title string, content []byte) (string, error) {
+	path = strings.TrimPrefix(path, "./")
+
+	r, _, err := c.githubClient.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	base := r.GetDefaultBranch()
+
+	file, _, _, err := c.githubClient.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: base})
+	if err != nil && !isNotFound(err) {
+		return "", err
+	}
+	if file != nil {
+		current, err := file.GetContent()
+		if err != nil {
+			return "", err
+		}
+		if current == string(content) {
+			return "", nil
+		}
+	}
+
+	baseRef, _, err := c.githubClient.Git.GetRef(ctx, owner, repo, "heads/"+base)
+	if err != nil {
+		return "", err
+	}
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: baseRef.Object.SHA},
+	}
+	if _, _, err := c.githubClient.Git.GetRef(ctx, owner, repo, "heads/"+branch); err == nil {
+		_, _, err = c.githubClient.Git.UpdateRef(ctx, owner, repo, ref, true)
+		if err != nil {
+			return "", err
+		}
+	} else if isNotFound(err) {
+		_, _, err = c.githubClient.Git.CreateRef(ctx, owner, repo, ref)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		return "", err
+	}
+
+	opt := &github.RepositoryContentFileOptions{
+		Message: github.String(title),
+		Content: content,
+		Branch:  github.String(branch),
+	}
+	if file != nil {
+		opt.SHA = file.SHA
+		_, _, err = c.githubClient.Repositories.UpdateFile(ctx, owner, repo, path, opt)
+	} else {
+		_, _, err = c.githubClient.Repositories.CreateFile(ctx, owner, repo, path, opt)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	prs, _, err := c.githubClient.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + branch,
+		Base:  base,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(prs) > 0 {
+		return prs[0].GetHTMLURL(), nil
+	}
+	pr, _, err := c.githubClient.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(branch),
+		Base:  github.String(base),
+		Body:  github.String("This pull request updates the label manifest to match the labels currently on the repository."),
+	})
+	if err != nil {
+		return "", err
+	}
+	return pr.GetHTMLURL(), nil
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	return ok && e.Response != nil && e.Response.StatusCode == http.StatusNotFound
+}
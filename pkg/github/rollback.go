@@ -0,0 +1,107 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WithRollback makes the Syncer revert the operations of a plan it has
+// already applied when applying the plan fails midway, so that the repository
+// isn't left half-synced. Deleted labels are created again, but not added back
+// to their issues.
+func WithRollback() SyncerOption {
+	return func(s *Syncer) {
+		s.rollback = true
+	}
+}
+
+// journal records the operations applied to a repository, in order. A nil
+// journal records nothing.
+type journal struct {
+	mu  sync.Mutex
+	ops []Operation
+}
+
+func (j *journal) record(op Operation) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ops = append(j.ops, op)
+}
+
+// undo returns the operation reverting op, applied to a repository having the
+// labels current, or false if it can't be reverted.
+func (op Operation) undo(current []Label) (Operation, bool) {
+	previous := op.Previous
+	if previous == nil {
+		name := op.Label.Name
+		if op.Kind == OperationRename {
+			name = op.From
+		}
+		for i := range current {
+			if current[i].Name == name {
+				previous = &current[i]
+				break
+			}
+		}
+	}
+
+	switch op.Kind {
+	case OperationCreate:
+		return Operation{Kind: OperationDelete, Label: op.Label}, true
+	case OperationDelete:
+		return Operation{Kind: OperationCreate, Label: op.Label}, true
+	case OperationUpdate, OperationRename:
+		if previous == nil {
+			return Operation{}, false
+		}
+		// An update may have changed the case of the name when resolving a
+		// conflict.
+		if previous.Name != op.Label.Name {
+			return Operation{Kind: OperationRename, Label: *previous, From: op.Label.Name, Previous: &op.Label}, true
+		}
+		return Operation{Kind: OperationUpdate, Label: *previous, Previous: &op.Label}, true
+	}
+	return Operation{}, false
+}
+
+// rollbackPlan reverts the operations of plan recorded in j, last first. It
+// stops at the first operation failing to be reverted.
+func (s *Syncer) rollbackPlan(ctx context.Context, plan *Plan, j *journal) error {
+	reverted := 0
+	for i := len(j.ops) - 1; i >= 0; i-- {
+		op, ok := j.ops[i].undo(plan.Current)
+		if !ok {
+			Warnf("label: %s %s on: %s/%s can't be rolled back", j.ops[i].Label.Name, j.ops[i].Kind, plan.Owner, plan.Repo)
+			continue
+		}
+		var log, out bytes.Buffer
+		err := s.apply(ctx, &log, nil, plan.Owner, plan.Repo, op)
+		printLog(&out, op.Kind, log.String())
+		flushLog(&out)
+		if err != nil {
+			return fmt.Errorf("unable to roll back labels: %w", err)
+		}
+		reverted++
+	}
+	fmt.Printf("labels on: %s/%s rolled back: %d operation(s) reverted\n", plan.Owner, plan.Repo, reverted)
+	return nil
+}
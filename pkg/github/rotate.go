@@ -0,0 +1,146 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// rotateBelow is the number of calls left in the rate limit of a token
+	// below which another token is used.
+	rotateBelow = 100
+	// fullRateLimit is the hourly rate limit of a personal access token.
+	fullRateLimit = 5000
+)
+
+// tokenRotator is a token source switching to another of its tokens when the
+// current one nears its rate limit, as told by the rate limit headers of the
+// responses it has seen.
+type tokenRotator struct {
+	mu      sync.Mutex
+	tokens  []string
+	current int
+	// remaining and reset are the rate limits of the tokens by index, known
+	// once a response to a request with the token has been seen.
+	remaining map[int]int
+	reset     map[int]time.Time
+}
+
+var _ oauth2.TokenSource = (*tokenRotator)(nil)
+
+// Token returns the current token, switching first to the token with the most
+// calls left if the current one has fewer than rotateBelow.
+func (r *tokenRotator) Token() (*oauth2.Token, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.left(r.current) < rotateBelow {
+		best := r.current
+		for i := range r.tokens {
+			if r.left(i) > r.left(best) {
+				best = i
+			}
+		}
+		if best != r.current {
+			fmt.Printf("token: switching from token #%d with %d call(s) left to token #%d\n", r.current+1, r.left(r.current), best+1)
+			r.current = best
+		}
+	}
+	return &oauth2.Token{AccessToken: r.tokens[r.current]}, nil
+}
+
+// left returns the calls left for token i, assuming a full rate limit if
+// unknown or reset since.
+func (r *tokenRotator) left(i int) int {
+	n, ok := r.remaining[i]
+	if !ok || time.Now().After(r.reset[i]) {
+		return fullRateLimit
+	}
+	return n
+}
+
+// observe records the rate limit of the token resp was sent with.
+func (r *tokenRotator) observe(req *http.Request, resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	auth := req.Header.Get("Authorization")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, t := range r.tokens {
+		if auth == "Bearer "+t {
+			r.remaining[i] = remaining
+			r.reset[i] = time.Unix(reset, 0)
+			return
+		}
+	}
+}
+
+// observer is the transport below the OAuth2 one, seeing the requests with
+// their token.
+type observer struct {
+	rotator *tokenRotator
+	base    http.RoundTripper
+}
+
+func (o *observer) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := o.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		o.rotator.observe(req, resp)
+	}
+	return resp, err
+}
+
+// NewRotatingClient is NewServerClient with several tokens, e.g. of different
+// accounts, to make more calls than the rate limit of a single token allows.
+// Calls are made with one token until it nears its rate limit, then with the
+// token with the most calls left.
+func NewRotatingClient(serverURL string, tokens []string) (*Client, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("no token")
+	}
+	r := &tokenRotator{
+		tokens:    tokens,
+		remaining: make(map[int]int),
+		reset:     make(map[int]time.Time),
+	}
+	tc := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: r,
+			Base:   &observer{rotator: r, base: http.DefaultClient.Transport},
+		},
+	}
+	if len(serverURL) == 0 {
+		return &Client{githubClient: github.NewClient(tc), httpClient: tc}, nil
+	}
+	return newServerClient(serverURL, tc)
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"sort"
+)
+
+// SelectSets returns the labels that apply to a repository selecting sets:
+// the labels without sets and the labels in any of sets. Without sets, every
+// label applies.
+func SelectSets(labels []Label, sets []string) []Label {
+	if len(sets) == 0 {
+		return labels
+	}
+	selected := make(map[string]bool, len(sets))
+	for _, s := range sets {
+		selected[s] = true
+	}
+	var ls []Label
+	for _, l := range labels {
+		if len(l.Sets) == 0 {
+			ls = append(ls, l)
+			continue
+		}
+		for _, s := range l.Sets {
+			if selected[s] {
+				ls = append(ls, l)
+				break
+			}
+		}
+	}
+	return ls
+}
+
+// LabelSets returns the sorted names of the sets labels are in.
+func LabelSets(labels []Label) []string {
+	seen := make(map[string]bool)
+	var sets []string
+	for _, l := range labels {
+		for _, s := range l.Sets {
+			if !seen[s] {
+				seen[s] = true
+				sets = append(sets, s)
+			}
+		}
+	}
+	sort.Strings(sets)
+	return sets
+}
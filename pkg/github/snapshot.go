@@ -0,0 +1,85 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ErrOffline is returned by Snapshot for changes to labels.
+var ErrOffline = errors.New("labels can't be changed offline")
+
+// Snapshot is a Provider serving the labels of repositories from a file rather
+// than an API, for plans made offline. It refuses changes.
+type Snapshot struct {
+	// all holds the labels of every repository if the snapshot is a label
+	// list, e.g. a backup.
+	all          []Label
+	repositories map[string][]Label
+}
+
+var _ Provider = (*Snapshot)(nil)
+
+// LoadSnapshot reads the snapshot at path: either a label list, e.g. a backup
+// written by WriteBackup, holding the labels of any repository, or a mapping of
+// owner/repo to the label list of that repository.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := yaml.Unmarshal(buf, &v); err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if _, ok := v.([]interface{}); ok {
+		err = yaml.Unmarshal(buf, &s.all)
+	} else {
+		err = yaml.Unmarshal(buf, &s.repositories)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Snapshot) ListLabels(ctx context.Context, owner, repo string) ([]Label, error) {
+	if s.repositories == nil {
+		return append([]Label(nil), s.all...), nil
+	}
+	labels, ok := s.repositories[owner+"/"+repo]
+	if !ok {
+		return nil, fmt.Errorf("repository %s/%s not in snapshot", owner, repo)
+	}
+	return append([]Label(nil), labels...), nil
+}
+
+func (s *Snapshot) CreateLabel(ctx context.Context, owner, repo string, label Label) error {
+	return ErrOffline
+}
+
+func (s *Snapshot) UpdateLabel(ctx context.Context, owner, repo, name string, label Label) error {
+	return ErrOffline
+}
+
+func (s *Snapshot) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	return ErrOffline
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// stampHashLength is the number of hex digits of the hash of the labels in
+// stamps, short enough for topics of at most 50 characters.
+const stampHashLength = 12
+
+// LabelsHash returns a short hash of labels, changing whenever a synced field
+// of any label does.
+func LabelsHash(labels []Label) (string, error) {
+	return shortHash(labels)
+}
+
+// SyncHash is LabelsHash also covering the reserved prefixes of the labels a
+// sync leaves alone, so that a stamp isn't current anymore once they change.
+// Without reserved prefixes, it's LabelsHash.
+func SyncHash(labels []Label, reserved []string) (string, error) {
+	if len(reserved) == 0 {
+		return LabelsHash(labels)
+	}
+	return shortHash(struct {
+		Labels   []Label  `json:"labels"`
+		Reserved []string `json:"reserved"`
+	}{labels, reserved})
+}
+
+func shortHash(v interface{}) (string, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])[:stampHashLength], nil
+}
+
+// StampTopic sets the topic name-hash on owner/repo, replacing the topics of
+// previous stamps with the same name. Repositories synced with a manifest can
+// then be found with a search for the topic.
+func (c *Client) StampTopic(ctx context.Context, owner, repo, name, hash string) error {
+	topics, _, err := c.githubClient.Repositories.ListAllTopics(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	stamp := name + "-" + hash
+	updated := []string{stamp}
+	for _, t := range topics {
+		if t == stamp {
+			return nil
+		}
+		if !strings.HasPrefix(t, name+"-") {
+			updated = append(updated, t)
+		}
+	}
+	_, _, err = c.githubClient.Repositories.ReplaceAllTopics(ctx, owner, repo, updated)
+	return err
+}
+
+// StampProperty sets the custom property name of owner/repo to hash. The
+// property must be defined by the organization.
+func (c *Client) StampProperty(ctx context.Context, owner, repo, name, hash string) error {
+	type property struct {
+		Name  string `json:"property_name"`
+		Value string `json:"value"`
+	}
+	body := struct {
+		Properties []property `json:"properties"`
+	}{
+		Properties: []property{{Name: name, Value: hash}},
+	}
+	u := fmt.Sprintf("repos/%s/%s/properties/values", owner, repo)
+	_, err := c.plainRequest(ctx, "PATCH", u, body, nil)
+	return err
+}
+
+// StampedTopic returns the hash of the topic stamp name on owner/repo, or an
+// empty string if it isn't stamped.
+func (c *Client) StampedTopic(ctx context.Context, owner, repo, name string) (string, error) {
+	topics, _, err := c.githubClient.Repositories.ListAllTopics(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range topics {
+		if strings.HasPrefix(t, name+"-") {
+			return strings.TrimPrefix(t, name+"-"), nil
+		}
+	}
+	return "", nil
+}
+
+// StampedProperties returns the values of the custom property name of the
+// repositories of org by repository name, listing every repository at once
+// rather than one call per repository. Owners not being organizations have no
+// custom properties.
+func (c *Client) StampedProperties(ctx context.Context, org, name string) (map[string]string, error) {
+	type repository struct {
+		Name       string `json:"repository_name"`
+		Properties []struct {
+			Name  string      `json:"property_name"`
+			Value interface{} `json:"value"`
+		} `json:"properties"`
+	}
+	values := make(map[string]string)
+	for page := 1; page != 0; {
+		var repos []repository
+		u := fmt.Sprintf("orgs/%s/properties/values?per_page=100&page=%d", org, page)
+		resp, err := c.plainRequest(ctx, "GET", u, nil, &repos)
+		if isNotFound(err) {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			for _, p := range r.Properties {
+				if s, ok := p.Value.(string); ok && p.Name == name {
+					values[r.Name] = s
+				}
+			}
+		}
+		page = resp.NextPage
+	}
+	return values, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import "testing"
+
+func TestSyncHash(t *testing.T) {
+	labels := []Label{{Name: "bug", Color: "d73a4a"}}
+	plain, err := LabelsHash(labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		labels   []Label
+		reserved []string
+		same     bool
+	}{
+		{name: "without reserved prefixes", labels: labels, same: true},
+		{name: "with reserved prefixes", labels: labels, reserved: []string{"ext/"}},
+		{name: "with other labels", labels: []Label{{Name: "bug", Color: "ffffff"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SyncHash(tt.labels, tt.reserved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != stampHashLength {
+				t.Errorf("got hash %q of length %d, want %d", got, len(got), stampHashLength)
+			}
+			if (got == plain) != tt.same {
+				t.Errorf("got hash %q, LabelsHash is %q", got, plain)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// State records the labels managed on each repository, i.e. created, updated
+// or adopted by the syncer. With a state, pruning only deletes managed labels
+// and leaves the labels created by hand alone.
+type State struct {
+	// Repositories maps owner/repo to the names of its managed labels.
+	Repositories map[string][]string `json:"repositories"`
+}
+
+// ReadState reads the state at path. A missing file is an empty state.
+func ReadState(path string) (*State, error) {
+	s := &State{Repositories: make(map[string][]string)}
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, s); err != nil {
+		return nil, err
+	}
+	if s.Repositories == nil {
+		s.Repositories = make(map[string][]string)
+	}
+	return s, nil
+}
+
+// WriteState writes s to path.
+func WriteState(path string, s *State) error {
+	buf, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(buf, '\n'), 0644)
+}
+
+// Move moves the managed labels of the repository from to the repository to,
+// e.g. after it has been renamed or transferred. s may be nil.
+func (s *State) Move(from, to string) {
+	if s == nil {
+		return
+	}
+	names, ok := s.Repositories[from]
+	if !ok {
+		return
+	}
+	if _, ok := s.Repositories[to]; !ok {
+		s.Repositories[to] = names
+	}
+	delete(s.Repositories, from)
+}
+
+// Managed returns the names of the managed labels of owner/repo.
+func (s *State) Managed(owner, repo string) map[string]bool {
+	names := make(map[string]bool)
+	for _, n := range s.Repositories[owner+"/"+repo] {
+		names[n] = true
+	}
+	return names
+}
+
+// Unmanaged returns the names of the current labels of p not managed on its
+// repository, for Keep.
+func (s *State) Unmanaged(p *Plan) map[string]bool {
+	managed := s.Managed(p.Owner, p.Repo)
+	names := make(map[string]bool)
+	for _, l := range p.Current {
+		if !managed[l.Name] {
+			names[l.Name] = true
+		}
+	}
+	return names
+}
+
+// Update records the labels of the repository of p as managed after p is
+// applied: labels, which may be nil, and the labels p creates, updates or
+// renames are added, and the labels it deletes or renames are removed.
+func (s *State) Update(p *Plan, labels []Label) {
+	managed := s.Managed(p.Owner, p.Repo)
+	for _, l := range labels {
+		managed[l.Name] = true
+	}
+	for _, op := range p.Operations {
+		switch op.Kind {
+		case OperationDelete:
+			delete(managed, op.Label.Name)
+		case OperationRename:
+			delete(managed, op.From)
+			managed[op.Label.Name] = true
+		default:
+			managed[op.Label.Name] = true
+		}
+	}
+	s.set(p.Owner, p.Repo, managed)
+}
+
+// Adopt records the current labels of owner/repo also in labels as managed
+// and returns their names, without changing anything on the repository.
+func (s *State) Adopt(owner, repo string, current, labels []Label) []string {
+	managed := s.Managed(owner, repo)
+	want := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		want[l.Name] = true
+	}
+	var adopted []string
+	for _, l := range current {
+		if want[l.Name] && !managed[l.Name] {
+			managed[l.Name] = true
+			adopted = append(adopted, l.Name)
+		}
+	}
+	s.set(owner, repo, managed)
+	return adopted
+}
+
+func (s *State) set(owner, repo string, managed map[string]bool) {
+	names := make([]string, 0, len(managed))
+	for n := range managed {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	s.Repositories[owner+"/"+repo] = names
+}
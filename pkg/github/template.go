@@ -0,0 +1,109 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Repository is the metadata of a repository available to description
+// templates as .Repo.
+type Repository struct {
+	Owner         string
+	Name          string
+	DefaultBranch string
+}
+
+// RepositoryGetter is implemented by providers able to return the metadata of
+// a repository.
+type RepositoryGetter interface {
+	GetRepository(ctx context.Context, owner, repo string) (*Repository, error)
+}
+
+// GetRepository returns the metadata of owner/repo.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
+	r, _, err := c.githubClient.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, wrapLabelError(err)
+	}
+	// GitHub redirects renamed and transferred repositories, so the response
+	// holds the current name.
+	return &Repository{
+		Owner:         r.GetOwner().GetLogin(),
+		Name:          r.GetName(),
+		DefaultBranch: r.GetDefaultBranch(),
+	}, nil
+}
+
+// renderLabels returns labels with their descriptions executed as templates
+// for owner/repo. The repository is only fetched if a description is a
+// template.
+func (s *Syncer) renderLabels(ctx context.Context, owner, repo string, labels []Label) ([]Label, error) {
+	if !templated(labels) {
+		return labels, nil
+	}
+	r := &Repository{Owner: owner, Name: repo}
+	if g, ok := s.provider.(RepositoryGetter); ok {
+		var err error
+		if r, err = g.GetRepository(ctx, owner, repo); err != nil {
+			return nil, fmt.Errorf("unable to get repository: %w", err)
+		}
+	}
+	return RenderLabels(labels, r)
+}
+
+// RenderLabels returns labels with their descriptions executed as templates
+// for r.
+func RenderLabels(labels []Label, r *Repository) ([]Label, error) {
+	data := struct{ Repo *Repository }{r}
+	rendered := make([]Label, len(labels))
+	for i, l := range labels {
+		rendered[i] = l
+		if !strings.Contains(l.Description, "{{") {
+			continue
+		}
+		desc, err := renderDescription(l.Description, data)
+		if err != nil {
+			return nil, fmt.Errorf("label %q: %w", l.Name, err)
+		}
+		rendered[i].Description = desc
+	}
+	return rendered, nil
+}
+
+func templated(labels []Label) bool {
+	for _, l := range labels {
+		if strings.Contains(l.Description, "{{") {
+			return true
+		}
+	}
+	return false
+}
+
+func renderDescription(description string, data interface{}) (string, error) {
+	t, err := template.New("description").Option("missingkey=error").Parse(description)
+	if err != nil {
+		return "", fmt.Errorf("invalid description template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid description template: %w", err)
+	}
+	return buf.String(), nil
+}
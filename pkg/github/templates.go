@@ -0,0 +1,92 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultLabels are the labels GitHub creates on new repositories.
+var DefaultLabels = []Label{
+	{Name: "bug", Description: "Something isn't working", Color: "d73a4a"},
+	{Name: "documentation", Description: "Improvements or additions to documentation", Color: "0075ca"},
+	{Name: "duplicate", Description: "This issue or pull request already exists", Color: "cfd3d7"},
+	{Name: "enhancement", Description: "New feature or request", Color: "a2eeef"},
+	{Name: "good first issue", Description: "Good for newcomers", Color: "7057ff"},
+	{Name: "help wanted", Description: "Extra attention is needed", Color: "008672"},
+	{Name: "invalid", Description: "This doesn't seem right", Color: "e4e669"},
+	{Name: "question", Description: "Further information is requested", Color: "d876e3"},
+	{Name: "wontfix", Description: "This will not be worked on", Color: "ffffff"},
+}
+
+var templates = map[string][]Label{
+	"minimal": DefaultLabels,
+	"kubernetes": {
+		{Name: "kind/bug", Description: "Categorizes issue or PR as related to a bug.", Color: "e11d21", Group: "kind"},
+		{Name: "kind/feature", Description: "Categorizes issue or PR as related to a new feature.", Color: "c7def8", Group: "kind"},
+		{Name: "kind/documentation", Description: "Categorizes issue or PR as related to documentation.", Color: "c7def8", Group: "kind"},
+		{Name: "kind/cleanup", Description: "Categorizes issue or PR as related to cleaning up code, process, or technical debt.", Color: "c7def8", Group: "kind"},
+		{Name: "kind/support", Description: "Categorizes issue or PR as a support question.", Color: "d455d0", Group: "kind"},
+		{Name: "priority/critical-urgent", Description: "Highest priority. Must be actively worked on as someone's top priority right now.", Color: "e11d21", Group: "priority"},
+		{Name: "priority/important-soon", Description: "Must be staffed and worked on either currently, or very soon.", Color: "eb6420", Group: "priority"},
+		{Name: "priority/important-longterm", Description: "Important over the long term, but may not be staffed and/or may need multiple releases.", Color: "eb6420", Group: "priority"},
+		{Name: "priority/backlog", Description: "Higher priority than priority/awaiting-more-evidence.", Color: "fbca04", Group: "priority"},
+		{Name: "priority/awaiting-more-evidence", Description: "Lowest priority. Possibly useful, but not yet enough support to actually get it done.", Color: "fef2c0", Group: "priority"},
+		{Name: "triage/accepted", Description: "Indicates an issue or PR is ready to be actively worked on.", Color: "8fc951", Group: "triage"},
+		{Name: "triage/duplicate", Description: "Indicates an issue is a duplicate of other open issue.", Color: "d455d0", Group: "triage"},
+		{Name: "triage/needs-information", Description: "Indicates an issue needs more information in order to work on it.", Color: "d455d0", Group: "triage"},
+		{Name: "triage/not-reproducible", Description: "Indicates an issue can not be reproduced as described.", Color: "d455d0", Group: "triage"},
+		{Name: "needs-triage", Description: "Indicates an issue or PR lacks a `triage/foo` label and requires one.", Color: "ededed", Group: "triage"},
+		{Name: "lifecycle/stale", Description: "Denotes an issue or PR has remained open with no activity and has become stale.", Color: "795548", Group: "lifecycle"},
+		{Name: "lifecycle/rotten", Description: "Denotes an issue or PR that has aged beyond stale and will be auto-closed.", Color: "604460", Group: "lifecycle"},
+		{Name: "lifecycle/frozen", Description: "Indicates that an issue or PR should not be auto-closed due to staleness.", Color: "d3e2f0", Group: "lifecycle"},
+		{Name: "good first issue", Description: "Denotes an issue ready for a new contributor.", Color: "7057ff"},
+		{Name: "help wanted", Description: "Denotes an issue that needs help from a contributor.", Color: "006b75"},
+	},
+	"conventional": {
+		{Name: "feat", Description: "A new feature", Color: "a2eeef", Group: "type"},
+		{Name: "fix", Description: "A bug fix", Color: "d73a4a", Group: "type"},
+		{Name: "docs", Description: "Documentation only changes", Color: "0075ca", Group: "type"},
+		{Name: "style", Description: "Changes that do not affect the meaning of the code", Color: "fef2c0", Group: "type"},
+		{Name: "refactor", Description: "A code change that neither fixes a bug nor adds a feature", Color: "c5def5", Group: "type"},
+		{Name: "perf", Description: "A code change that improves performance", Color: "fbca04", Group: "type"},
+		{Name: "test", Description: "Adding missing tests or correcting existing tests", Color: "bfd4f2", Group: "type"},
+		{Name: "build", Description: "Changes that affect the build system or external dependencies", Color: "5319e7", Group: "type"},
+		{Name: "ci", Description: "Changes to CI configuration files and scripts", Color: "1d76db", Group: "type"},
+		{Name: "chore", Description: "Other changes that don't modify src or test files", Color: "ededed", Group: "type"},
+		{Name: "revert", Description: "Reverts a previous commit", Color: "b60205", Group: "type"},
+		{Name: "breaking change", Description: "Introduces a breaking API change", Color: "b60205"},
+	},
+}
+
+// Template returns the labels of the named starter manifest.
+func Template(name string) ([]Label, error) {
+	labels, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template: %s (available: %v)", name, TemplateNames())
+	}
+	return labels, nil
+}
+
+// TemplateNames returns the names of the available templates.
+func TemplateNames() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
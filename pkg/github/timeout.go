@@ -0,0 +1,48 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// WithCallTimeout makes the Syncer give up on a call to the provider, i.e.
+// listing the labels of a repository or a single operation, after timeout, and
+// warn about calls taking longer than slow, e.g. because of an overloaded
+// server. Zero disables either.
+func WithCallTimeout(timeout, slow time.Duration) SyncerOption {
+	return func(s *Syncer) {
+		s.callTimeout = timeout
+		s.slowCall = slow
+	}
+}
+
+// call runs f with the call timeout of s, writing a warning describing the
+// call as what to w if it's slow.
+func (s *Syncer) call(ctx context.Context, w io.Writer, what string, f func(context.Context) error) error {
+	if s.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.callTimeout)
+		defer cancel()
+	}
+	start := time.Now()
+	err := f(ctx)
+	if d := time.Since(start); s.slowCall > 0 && d > s.slowCall {
+		Fwarnf(w, "%s took %s", what, d.Round(time.Millisecond))
+	}
+	return err
+}
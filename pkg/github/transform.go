@@ -0,0 +1,71 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NameTransform changes the name of a label, e.g. to adapt a shared manifest
+// to the naming convention of an organization.
+type NameTransform func(name string) string
+
+var kebabRegexp = regexp.MustCompile(`[\s_]+`)
+
+// ParseNameTransform parses spec, one of "lowercase", "kebab-case" (lowercase
+// with spaces and underscores replaced by hyphens) and "prefix:<prefix>".
+func ParseNameTransform(spec string) (NameTransform, error) {
+	switch {
+	case spec == "lowercase":
+		return strings.ToLower, nil
+	case spec == "kebab-case":
+		return func(name string) string {
+			return kebabRegexp.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+		}, nil
+	case strings.HasPrefix(spec, "prefix:"):
+		prefix := strings.TrimPrefix(spec, "prefix:")
+		return func(name string) string {
+			return prefix + name
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown name transform: %s", spec)
+}
+
+// TransformNames returns labels with transforms applied to their names in
+// order, including to the names they're renamed from.
+func TransformNames(labels []Label, transforms []NameTransform) []Label {
+	if len(transforms) == 0 {
+		return labels
+	}
+	apply := func(name string) string {
+		if len(name) == 0 {
+			return name
+		}
+		for _, t := range transforms {
+			name = t(name)
+		}
+		return name
+	}
+	transformed := make([]Label, len(labels))
+	for i, l := range labels {
+		transformed[i] = l
+		transformed[i].Name = apply(l.Name)
+		transformed[i].NewName = apply(l.NewName)
+		transformed[i].from = apply(l.from)
+	}
+	return transformed
+}
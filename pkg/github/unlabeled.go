@@ -0,0 +1,50 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+// UnlabeledIssues returns the numbers of the open issues of owner/repo without
+// any label, e.g. transferred from another repository or stripped of their
+// only label by a prune. Pull requests aren't included. The search API finds
+// them without listing every open issue.
+func (c *Client) UnlabeledIssues(ctx context.Context, owner, repo string) ([]int, error) {
+	query := fmt.Sprintf("repo:%s/%s is:issue is:open no:label", owner, repo)
+	opt := &github.SearchOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	var numbers []int
+	for {
+		result, resp, err := c.githubClient.Search.Issues(ctx, query, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range result.Issues {
+			numbers = append(numbers, issue.GetNumber())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return numbers, nil
+}
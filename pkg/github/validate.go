@@ -0,0 +1,164 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"go.uber.org/multierr"
+)
+
+// Limits enforced by GitHub on label fields.
+const (
+	maxNameLength        = 50
+	maxDescriptionLength = 100
+)
+
+var (
+	colorRegexp      = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+	shortColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{3}$`)
+)
+
+// ValidateManifest parses the manifest at path, rejecting unknown fields, and
+// validates the labels, as LoadManifest returns them, and milestones in it.
+// It doesn't call the GitHub API.
+func ValidateManifest(path string) (*Manifest, error) {
+	m, err := ParseManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.render(); err != nil {
+		return nil, err
+	}
+	return m, multierr.Append(ValidateLabels(m.Labels), ValidateMilestones(m.Milestones))
+}
+
+// ValidateLabels returns every problem found in labels combined into a single
+// error, or nil if the labels are valid.
+func ValidateLabels(labels []Label) error {
+	var err error
+	seen := make(map[string]int)
+	for i, l := range labels {
+		if len(l.Name) == 0 {
+			err = multierr.Append(err, fmt.Errorf("label #%d: name is required", i+1))
+			continue
+		}
+		if n := utf8.RuneCountInString(l.Name); n > maxNameLength {
+			err = multierr.Append(err, fmt.Errorf("label %q: name is %d characters long (max %d)", l.Name, n, maxNameLength))
+		}
+		if j, ok := seen[collisionKey(l.Name)]; ok {
+			prev := labels[j].Name
+			if prev == l.Name {
+				err = multierr.Append(err, fmt.Errorf("label %q: duplicates label #%d", l.Name, j+1))
+			} else {
+				err = multierr.Append(err, fmt.Errorf("label %q: collides with label #%d %q, differing only by %s", l.Name, j+1, prev, collisionReason(l.Name, prev)))
+			}
+		} else {
+			seen[collisionKey(l.Name)] = i
+		}
+		if !colorRegexp.MatchString(l.Color) {
+			err = multierr.Append(err, fmt.Errorf("label %q: color %q must be 6 hex digits without '#'", l.Name, l.Color))
+		}
+		if strings.Contains(l.Description, "{{") {
+			// The length of templates is only known once executed.
+			if _, e := template.New("description").Parse(l.Description); e != nil {
+				err = multierr.Append(err, fmt.Errorf("label %q: invalid description template: %w", l.Name, e))
+			}
+		} else if n := utf8.RuneCountInString(l.Description); n > maxDescriptionLength {
+			err = multierr.Append(err, fmt.Errorf("label %q: description is %d characters long (max %d)", l.Name, n, maxDescriptionLength))
+		}
+		for _, locale := range l.locales() {
+			if n := utf8.RuneCountInString(l.Descriptions[locale]); n > maxDescriptionLength {
+				err = multierr.Append(err, fmt.Errorf("label %q: %s description is %d characters long (max %d)", l.Name, locale, n, maxDescriptionLength))
+			}
+		}
+		switch l.Policy {
+		case "", PolicyCreateOnly, PolicyEnforce:
+		default:
+			err = multierr.Append(err, fmt.Errorf("label %q: unknown policy: %s", l.Name, l.Policy))
+		}
+		for _, s := range l.Sets {
+			if len(strings.TrimSpace(s)) == 0 || strings.ContainsAny(s, ", ") {
+				err = multierr.Append(err, fmt.Errorf("label %q: invalid set name: %q", l.Name, s))
+			}
+		}
+		if l.Priority != nil && *l.Priority < 0 {
+			err = multierr.Append(err, fmt.Errorf("label %q: priority %d must not be negative", l.Name, *l.Priority))
+		}
+		if l.Match != nil {
+			if len(l.Match.Title) == 0 && len(l.Match.Body) == 0 {
+				err = multierr.Append(err, fmt.Errorf("label %q: match requires title or body", l.Name))
+			}
+			if _, e := l.Match.compile(); e != nil {
+				err = multierr.Append(err, fmt.Errorf("label %q: %w", l.Name, e))
+			}
+		}
+	}
+	return err
+}
+
+// Validate returns every operation of p the GitHub API would reject, combined
+// into a single error, so that a plan passing it doesn't fail halfway
+// through. Unlike ValidateLabels, it checks labels as they are sent, i.e. with
+// templates executed, and against the labels already on the repository.
+func (p *Plan) Validate() error {
+	var err error
+	names := make(map[string]string)
+	for _, l := range p.Current {
+		names[strings.ToLower(l.Name)] = l.Name
+	}
+	for _, op := range p.Operations {
+		switch op.Kind {
+		case OperationDelete:
+			delete(names, strings.ToLower(op.Label.Name))
+		case OperationRename:
+			delete(names, strings.ToLower(op.From))
+		}
+	}
+
+	for _, op := range p.Operations {
+		if op.Kind == OperationDelete {
+			continue
+		}
+		l := op.Label
+		if len(l.Name) == 0 {
+			err = multierr.Append(err, fmt.Errorf("label to %s: name is required", op.Kind))
+			continue
+		}
+		if n := utf8.RuneCountInString(l.Name); n > maxNameLength {
+			err = multierr.Append(err, fmt.Errorf("label %q: name is %d characters long (max %d)", l.Name, n, maxNameLength))
+		}
+		if !colorRegexp.MatchString(l.Color) {
+			err = multierr.Append(err, fmt.Errorf("label %q: color %q must be 6 hex digits without '#'", l.Name, l.Color))
+		}
+		if n := utf8.RuneCountInString(l.Description); n > maxDescriptionLength {
+			err = multierr.Append(err, fmt.Errorf("label %q: description is %d characters long (max %d)", l.Name, n, maxDescriptionLength))
+		}
+		if op.Kind == OperationUpdate {
+			continue
+		}
+		// GitHub compares label names case-insensitively.
+		if prev, ok := names[strings.ToLower(l.Name)]; ok {
+			err = multierr.Append(err, fmt.Errorf("label %q: conflicts with label %q on: %s/%s", l.Name, prev, p.Owner, p.Repo))
+			continue
+		}
+		names[strings.ToLower(l.Name)] = l.Name
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 micnncim
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	priority := -1
+	tests := []struct {
+		name   string
+		labels []Label
+		want   string
+	}{
+		{
+			name:   "valid",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Description: "Something isn't working"}, {Name: "docs", Color: "0075ca"}},
+		},
+		{
+			name:   "missing name",
+			labels: []Label{{Color: "d73a4a"}},
+			want:   "label #1: name is required",
+		},
+		{
+			name:   "long name",
+			labels: []Label{{Name: strings.Repeat("a", maxNameLength+1), Color: "d73a4a"}},
+			want:   "characters long",
+		},
+		{
+			name:   "duplicate",
+			labels: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "bug", Color: "d73a4a"}},
+			want:   `label "bug": duplicates label #1`,
+		},
+		{
+			name:   "case collision",
+			labels: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "Bug", Color: "d73a4a"}},
+			want:   "differing only by case",
+		},
+		{
+			name:   "color with '#'",
+			labels: []Label{{Name: "bug", Color: "#d73a4a"}},
+			want:   "must be 6 hex digits",
+		},
+		{
+			name:   "long description",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Description: strings.Repeat("a", maxDescriptionLength+1)}},
+			want:   "description is",
+		},
+		{
+			name:   "invalid description template",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Description: "{{ .Name"}},
+			want:   "invalid description template",
+		},
+		{
+			name:   "unknown policy",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Policy: "sometimes"}},
+			want:   "unknown policy: sometimes",
+		},
+		{
+			name:   "invalid set",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Sets: []string{"a,b"}}},
+			want:   "invalid set name",
+		},
+		{
+			name:   "negative priority",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Priority: &priority}},
+			want:   "must not be negative",
+		},
+		{
+			name:   "match without rules",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Match: &Match{}}},
+			want:   "match requires title or body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, ValidateLabels(tt.labels), tt.want)
+		})
+	}
+}
+
+func TestPlanValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []Label
+		labels  []Label
+		prune   bool
+		want    string
+	}{
+		{
+			name:    "valid",
+			current: []Label{{Name: "bug", Color: "ffffff"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+		},
+		{
+			name:    "case rename without prune",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+		},
+		{
+			name:    "case rename with prune",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}, {Name: "extra", Color: "d73a4a"}},
+			labels:  []Label{{Name: "bug", Color: "d73a4a"}},
+			prune:   true,
+		},
+		{
+			name:    "created over deleted labels differing by case",
+			current: []Label{{Name: "old", Color: "d73a4a"}, {Name: "OLD", Color: "d73a4a"}},
+			labels:  []Label{{Name: "Old", Color: "ffffff"}},
+			prune:   true,
+		},
+		{
+			name:    "conflicting with a label kept on the repository",
+			current: []Label{{Name: "Bug", Color: "d73a4a"}},
+			labels:  []Label{{Name: "Bug", Color: "d73a4a"}, {Name: "bug", Color: "d73a4a"}},
+			want:    `label "bug": conflicts with label "Bug" on: owner/repo`,
+		},
+		{
+			name:   "invalid color",
+			labels: []Label{{Name: "bug", Color: "red"}},
+			want:   `label "bug": color "red" must be 6 hex digits`,
+		},
+		{
+			name:   "long description",
+			labels: []Label{{Name: "bug", Color: "d73a4a", Description: strings.Repeat("a", maxDescriptionLength+1)}},
+			want:   "description is",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := NewPlan("owner", "repo", tt.current, tt.labels, tt.prune)
+			assertError(t, plan.Validate(), tt.want)
+		})
+	}
+}
+
+// assertError fails t unless err contains want, or is nil if want is empty.
+func assertError(t *testing.T, err error, want string) {
+	t.Helper()
+	switch {
+	case len(want) == 0 && err != nil:
+		t.Errorf("got error %v, want none", err)
+	case len(want) > 0 && err == nil:
+		t.Errorf("got no error, want %q", want)
+	case len(want) > 0 && !strings.Contains(err.Error(), want):
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
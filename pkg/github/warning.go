@@ -0,0 +1,42 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+var warnings int32
+
+// Warnf prints a warning line to the standard output and counts it in
+// Warnings.
+func Warnf(format string, a ...interface{}) {
+	Fwarnf(os.Stdout, format, a...)
+}
+
+// Fwarnf is Warnf writing to w.
+func Fwarnf(w io.Writer, format string, a ...interface{}) {
+	atomic.AddInt32(&warnings, 1)
+	fmt.Fprintf(w, "warning: "+format+"\n", a...)
+}
+
+// Warnings returns the number of warnings printed so far, e.g. to fail a run
+// with warnings.
+func Warnings() int {
+	return int(atomic.LoadInt32(&warnings))
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitlab implements the label provider for GitLab project labels.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+const DefaultURL = "https://gitlab.com"
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// label is a GitLab project label.
+type label struct {
+	Name        string `json:"name"`
+	NewName     string `json:"new_name,omitempty"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Priority    *int   `json:"priority,omitempty"`
+}
+
+var (
+	_ github.Provider         = (*Client)(nil)
+	_ github.RepositoryGetter = (*Client)(nil)
+	_ github.FieldSupporter   = (*Client)(nil)
+)
+
+// NewClient returns a client of the GitLab instance at baseURL, e.g.
+// https://gitlab.com.
+func NewClient(baseURL, token string) *Client {
+	if len(baseURL) == 0 {
+		baseURL = DefaultURL
+	}
+	return &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		token:      token,
+	}
+}
+
+// ListLabels returns the labels of the project owner/repo, where owner is the
+// full path of its group.
+func (c *Client) ListLabels(ctx context.Context, owner, repo string) ([]github.Label, error) {
+	var labels []github.Label
+	page := "1"
+	for len(page) > 0 {
+		q := url.Values{}
+		q.Set("per_page", "100")
+		q.Set("page", page)
+		// Group labels can't be managed through the project.
+		q.Set("include_ancestor_groups", "false")
+
+		var ls []label
+		resp, err := c.do(ctx, http.MethodGet, c.labelsURL(owner, repo)+"?"+q.Encode(), nil, &ls)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range ls {
+			labels = append(labels, github.Label{
+				Name:        l.Name,
+				Description: l.Description,
+				Color:       github.NormalizeColor(l.Color),
+				Priority:    l.Priority,
+			})
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return labels, nil
+}
+
+// CreateLabel creates label on the project owner/repo.
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, l github.Label) error {
+	body := &label{
+		Name:        l.Name,
+		Description: l.Description,
+		Color:       "#" + l.Color,
+		Priority:    l.Priority,
+	}
+	_, err := c.do(ctx, http.MethodPost, c.labelsURL(owner, repo), body, nil)
+	return err
+}
+
+// UpdateLabel updates the label name on the project owner/repo to l, renaming
+// it if the names differ.
+func (c *Client) UpdateLabel(ctx context.Context, owner, repo, name string, l github.Label) error {
+	body := &label{
+		Description: l.Description,
+		Color:       "#" + l.Color,
+		Priority:    l.Priority,
+	}
+	if l.Name != name {
+		body.NewName = l.Name
+	}
+	_, err := c.do(ctx, http.MethodPut, c.labelURL(owner, repo, name), body, nil)
+	return err
+}
+
+// DeleteLabel deletes the label name from the project owner/repo.
+func (c *Client) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.labelURL(owner, repo, name), nil, nil)
+	return err
+}
+
+// SupportsField reports whether field is supported, which only priority is.
+func (c *Client) SupportsField(field string) bool {
+	return field == github.FieldPriority
+}
+
+// GetRepository returns the metadata of the project owner/repo.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	var p struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, c.projectURL(owner, repo), nil, &p); err != nil {
+		return nil, err
+	}
+	return &github.Repository{
+		Owner:         owner,
+		Name:          repo,
+		DefaultBranch: p.DefaultBranch,
+	}, nil
+}
+
+func (c *Client) projectURL(owner, repo string) string {
+	return fmt.Sprintf("%s/projects/%s", c.baseURL, url.PathEscape(owner+"/"+repo))
+}
+
+func (c *Client) labelsURL(owner, repo string) string {
+	return c.projectURL(owner, repo) + "/labels"
+}
+
+func (c *Client) labelURL(owner, repo, name string) string {
+	return c.labelsURL(owner, repo) + "/" + url.PathEscape(name)
+}
+
+// do sends a request with body encoded as JSON and decodes the response into v
+// if not nil.
+func (c *Client) do(ctx context.Context, method, u string, body, v interface{}) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return nil, &github.HTTPError{
+			Method:     method,
+			URL:        u,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       strings.TrimSpace(string(b)),
+			Header:     resp.Header,
+		}
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
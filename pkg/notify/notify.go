@@ -0,0 +1,72 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify posts the results of syncs to webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// Result is the outcome of syncing the labels of a repository.
+type Result struct {
+	Repository string             `json:"repository"`
+	Operations []github.Operation `json:"operations,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// Send posts results to the webhook at url in format "json", the results as
+// they are, or "slack", a Block Kit message for Slack incoming webhooks.
+func Send(ctx context.Context, url, format string, results []Result) error {
+	var v interface{}
+	switch format {
+	case "json":
+		v = struct {
+			Results []Result `json:"results"`
+		}{results}
+	case "slack":
+		v = slackMessage(results)
+	default:
+		return fmt.Errorf("unknown notify format: %s", format)
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
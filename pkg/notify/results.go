@@ -0,0 +1,90 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// resultsFile is the format of result files, the same as of JSON
+// notifications.
+type resultsFile struct {
+	Results []Result `json:"results"`
+}
+
+// WriteResults writes results to path as JSON, e.g. for a matrix leg to upload
+// as an artifact.
+func WriteResults(path string, results []Result) error {
+	if results == nil {
+		results = []Result{}
+	}
+	buf, err := json.MarshalIndent(resultsFile{Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(buf, '\n'), 0644)
+}
+
+// ParseResults parses results written by WriteResults.
+func ParseResults(buf []byte) ([]Result, error) {
+	var f resultsFile
+	if err := json.Unmarshal(buf, &f); err != nil {
+		return nil, err
+	}
+	return f.Results, nil
+}
+
+// Failed returns the number of results with an error.
+func Failed(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if len(r.Error) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary returns results as Markdown, a table with a row per repository
+// under a verdict, for a job summary.
+func Summary(results []Result) string {
+	var b strings.Builder
+	failed := Failed(results)
+	if failed > 0 {
+		fmt.Fprintf(&b, "## :x: Label sync failed on %d of %d repositories\n\n", failed, len(results))
+	} else {
+		fmt.Fprintf(&b, "## :white_check_mark: Labels synced on %d repositories\n\n", len(results))
+	}
+	if len(results) == 0 {
+		return b.String()
+	}
+	b.WriteString("| Repository | Status | Create | Update | Rename | Delete |\n")
+	b.WriteString("| --- | --- | --: | --: | --: | --: |\n")
+	for _, r := range results {
+		counts := make(map[string]int)
+		for _, op := range r.Operations {
+			counts[string(op.Kind)]++
+		}
+		status := "synced"
+		if len(r.Error) > 0 {
+			status = "failed: " + strings.Replace(strings.Replace(r.Error, "|", `\|`, -1), "\n", " ", -1)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %d |\n", r.Repository, status, counts["create"], counts["update"], counts["rename"], counts["delete"])
+	}
+	return b.String()
+}
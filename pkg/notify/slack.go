@@ -0,0 +1,120 @@
+// Copyright 2020 micnncim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/micnncim/action-label-syncer/pkg/github"
+)
+
+// Slack limits the text of a section to 3000 characters.
+const maxSectionLength = 3000
+
+type block struct {
+	Type string `json:"type"`
+	Text *text  `json:"text,omitempty"`
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// swatches are the emojis showing label colors, with the colors they stand
+// for.
+var swatches = []struct {
+	emoji string
+	hex   string
+}{
+	{":red_circle:", "dd2e44"},
+	{":large_orange_circle:", "f4900c"},
+	{":large_yellow_circle:", "fdcb58"},
+	{":large_green_circle:", "78b159"},
+	{":large_blue_circle:", "55acee"},
+	{":large_purple_circle:", "aa8ed6"},
+	{":large_brown_circle:", "c1694f"},
+	{":black_circle:", "31373d"},
+	{":white_circle:", "e6e7e8"},
+}
+
+// slackMessage returns a Block Kit message with a section per repository.
+func slackMessage(results []Result) interface{} {
+	var (
+		blocks []block
+		failed int
+	)
+	for _, r := range results {
+		var b strings.Builder
+		if len(r.Error) > 0 {
+			failed++
+			fmt.Fprintf(&b, ":x: *%s* failed:\n```%s```\n", r.Repository, r.Error)
+		} else {
+			fmt.Fprintf(&b, ":white_check_mark: *%s*\n", r.Repository)
+		}
+		for _, op := range r.Operations {
+			line := fmt.Sprintf("%s `%s` %sd\n", swatch(op.Label.Color), op.Label.Name, op.Kind)
+			if op.Kind == github.OperationRename {
+				line = fmt.Sprintf("%s `%s` renamed to `%s`\n", swatch(op.Label.Color), op.From, op.Label.Name)
+			}
+			if b.Len()+len(line) > maxSectionLength {
+				b.WriteString("…\n")
+				break
+			}
+			b.WriteString(line)
+		}
+		blocks = append(blocks, block{Type: "section", Text: &text{Type: "mrkdwn", Text: b.String()}})
+	}
+
+	summary := fmt.Sprintf("Labels synced on %d repositories", len(results))
+	if failed > 0 {
+		summary = fmt.Sprintf("Labels synced on %d repositories, %d failed", len(results), failed)
+	}
+	header := block{Type: "header", Text: &text{Type: "plain_text", Text: summary}}
+	return struct {
+		Text   string  `json:"text"`
+		Blocks []block `json:"blocks"`
+	}{
+		Text:   summary,
+		Blocks: append([]block{header}, blocks...),
+	}
+}
+
+// swatch returns the emoji closest to color.
+func swatch(color string) string {
+	r, g, b, ok := rgb(color)
+	if !ok {
+		return ":white_circle:"
+	}
+	best, min := swatches[0].emoji, -1
+	for _, s := range swatches {
+		sr, sg, sb, _ := rgb(s.hex)
+		d := (r-sr)*(r-sr) + (g-sg)*(g-sg) + (b-sb)*(b-sb)
+		if min < 0 || d < min {
+			best, min = s.emoji, d
+		}
+	}
+	return best
+}
+
+func rgb(color string) (int, int, int, bool) {
+	n, err := strconv.ParseUint(github.NormalizeColor(color), 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff), true
+}